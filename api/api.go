@@ -4,16 +4,17 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ashalfarhan/realworld/cache/store"
 	"github.com/ashalfarhan/realworld/config"
 	"github.com/ashalfarhan/realworld/service"
 )
 
-func InitServer(serv *service.Service) *http.Server {
-	r := InitRoutes(serv)
+func InitServer(serv *service.Service, rates store.RateStore) *http.Server {
+	r := InitRoutes(serv, rates)
 	return &http.Server{
 		Addr:         config.Addr,
 		Handler:      r,
-		WriteTimeout: 5 * time.Second,
+		WriteTimeout: 0, // Streams stay open, other routes are bounded by middleware.Timeout
 		ReadTimeout:  5 * time.Second,
 		IdleTimeout:  5 * time.Second,
 	}
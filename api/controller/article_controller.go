@@ -43,6 +43,12 @@ func (c *ArticleController) CreateArticle(w http.ResponseWriter, r *http.Request
 }
 
 func (c *ArticleController) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
+	format, err := getFormatQueryParam(r.URL.Query())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
 	uid, err := jwt.GetUsernameFromReq(r)
 	if err != nil {
 		response.Err(w, err)
@@ -54,8 +60,8 @@ func (c *ArticleController) GetArticleBySlug(w http.ResponseWriter, r *http.Requ
 		response.Err(w, err)
 		return
 	}
-	response.Ok(w, response.M{
-		"article": a.Serialize(),
+	response.Cached(w, r, &response.Validator{LastModified: a.UpdatedAt, Version: a.Version}, response.M{
+		"article": a.SerializeFormat(format),
 	})
 }
 
@@ -68,17 +74,6 @@ func (c *ArticleController) DeleteArticle(w http.ResponseWriter, r *http.Request
 	response.Accepted(w, nil)
 }
 
-func (c *ArticleController) GetAllTags(w http.ResponseWriter, r *http.Request) {
-	tags, err := c.articleService.GetAllTags(r.Context())
-	if err != nil {
-		response.Err(w, err)
-		return
-	}
-	response.Ok(w, response.M{
-		"tags": tags,
-	})
-}
-
 func (c *ArticleController) UpdateArticle(w http.ResponseWriter, r *http.Request) {
 	req := new(model.UpdateArticleDto)
 	if err := utils.ValidateDTO(r, req); err != nil {
@@ -86,6 +81,7 @@ func (c *ArticleController) UpdateArticle(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	req.Article.IfMatch = response.IfMatchVersions(r)
 	iu := jwt.CurrentUser(r)
 	ar, err := c.articleService.UpdateArticleBySlug(r.Context(), iu, mux.Vars(r)["slug"], req.Article)
 	if err != nil {
@@ -104,13 +100,18 @@ func (c *ArticleController) GetFiltered(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if args.Username, err = jwt.GetUsernameFromReq(r); err != nil {
+		response.Err(w, err)
+		return
+	}
+
 	articles, err := c.articleService.GetArticles(r.Context(), args)
 	if err != nil {
 		response.Err(w, err)
 		return
 	}
-	response.Ok(w, response.M{
-		"articles":      articles.Serialize(),
+	response.Cached(w, r, &response.Validator{LastModified: articles.LastUpdatedAt(), Weak: true}, response.M{
+		"articles":      articles.SerializeFormat(args.Format),
 		"articlesCount": len(articles),
 	})
 }
@@ -123,13 +124,14 @@ func (c *ArticleController) GetFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	args.Username = jwt.CurrentUser(r)
+	args.Feed = true
 	articles, err := c.articleService.GetArticlesFeed(r.Context(), args)
 	if err != nil {
 		response.Err(w, err)
 		return
 	}
-	response.Ok(w, response.M{
-		"articles":      articles.Serialize(),
+	response.Cached(w, r, &response.Validator{LastModified: articles.LastUpdatedAt(), Weak: true}, response.M{
+		"articles":      articles.SerializeFormat(args.Format),
 		"articlesCount": len(articles),
 	})
 }
@@ -162,9 +164,12 @@ func getArticleQueryParams(q url.Values) (*model.FindArticlesArgs, *model.Condui
 	var err error
 	limit, offset := q.Get("limit"), q.Get("offset")
 	args := &model.FindArticlesArgs{
-		Tag:       q.Get("tag"),
-		Author:    q.Get("author"),
-		Favorited: q.Get("favorited"),
+		Tag:        q.Get("tag"),
+		Author:     q.Get("author"),
+		Favorited:  q.Get("favorited"),
+		FeedMode:   q.Get("mode"),
+		Collection: q.Get("collection"),
+		Format:     q.Get("format"),
 	}
 
 	if limit == "" {
@@ -189,3 +194,16 @@ func getArticleQueryParams(q url.Values) (*model.FindArticlesArgs, *model.Condui
 	}
 	return args, nil
 }
+
+// "?format=html" adds the rendered body next to the raw markdown
+func getFormatQueryParam(q url.Values) (string, *model.ConduitError) {
+	args := struct {
+		Format string `validate:"omitempty,oneof=markdown html"`
+	}{q.Get("format")}
+
+	v := validator.New()
+	if err := v.Struct(args); err != nil {
+		return "", conduit.BuildError(http.StatusUnprocessableEntity, err)
+	}
+	return args.Format, nil
+}
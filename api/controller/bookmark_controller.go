@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/utils"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/gorilla/mux"
+)
+
+func (c *ArticleController) BookmarkArticle(w http.ResponseWriter, r *http.Request) {
+	// The body is optional, without it the bookmark is left uncategorized
+	req := &model.BookmarkDto{Bookmark: &model.BookmarkFields{}}
+	if r.ContentLength != 0 {
+		if err := utils.ValidateDTO(r, req); err != nil {
+			response.Err(w, err)
+			return
+		}
+	}
+
+	iu := jwt.CurrentUser(r)
+	a, err := c.articleService.BookmarkArticleBySlug(r.Context(), iu, mux.Vars(r)["slug"], req.Bookmark.Collection)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, response.M{
+		"article": a.Serialize(),
+	})
+}
+
+func (c *ArticleController) UnbookmarkArticle(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	a, err := c.articleService.UnbookmarkArticleBySlug(r.Context(), iu, mux.Vars(r)["slug"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, response.M{
+		"article": a.Serialize(),
+	})
+}
+
+func (c *ArticleController) GetBookmarks(w http.ResponseWriter, r *http.Request) {
+	args, err := getArticleQueryParams(r.URL.Query())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	args.Username = jwt.CurrentUser(r)
+	articles, err := c.articleService.GetBookmarks(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"articles":      articles.SerializeFormat(args.Format),
+		"articlesCount": len(articles),
+	})
+}
+
+func (c *ArticleController) GetBookmarkCollections(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	collections, err := c.articleService.GetBookmarkCollections(r.Context(), iu)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"collections": collections,
+	})
+}
+
+func (c *ArticleController) DeleteBookmarkCollection(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	if err := c.articleService.DeleteBookmarkCollection(r.Context(), iu, mux.Vars(r)["name"]); err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, nil)
+}
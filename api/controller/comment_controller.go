@@ -2,11 +2,15 @@ package controller
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/conduit"
 	"github.com/ashalfarhan/realworld/model"
 	"github.com/ashalfarhan/realworld/utils"
 	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 )
 
@@ -27,6 +31,37 @@ func (c *ArticleController) CreateComment(w http.ResponseWriter, r *http.Request
 	})
 }
 
+func (c *ArticleController) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	req := new(model.UpdateCommentDto)
+	if err := utils.ValidateDTO(r, req); err != nil {
+		response.Err(w, err)
+		return
+	}
+	iu := jwt.CurrentUser(r)
+	vars := mux.Vars(r)
+	comm, err := c.articleService.UpdateComment(r.Context(), req.Comment, iu, vars["slug"], vars["id"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, response.M{
+		"comment": comm,
+	})
+}
+
+func (c *ArticleController) GetCommentEdits(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	vars := mux.Vars(r)
+	edits, err := c.articleService.GetCommentEdits(r.Context(), iu, vars["slug"], vars["id"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"edits": edits,
+	})
+}
+
 func (c *ArticleController) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	iu := jwt.CurrentUser(r)
 	if err := c.articleService.DeleteCommentByID(r.Context(), mux.Vars(r)["id"], iu); err != nil {
@@ -37,17 +72,66 @@ func (c *ArticleController) DeleteComment(w http.ResponseWriter, r *http.Request
 }
 
 func (c *ArticleController) GetArticleComments(w http.ResponseWriter, r *http.Request) {
-	username, err := jwt.GetUsernameFromReq(r)
+	args, err := getCommentQueryParams(r.URL.Query())
 	if err != nil {
 		response.Err(w, err)
 		return
 	}
-	comms, err := c.articleService.GetComments(r.Context(), mux.Vars(r)["slug"], username)
+
+	if args.Username, err = jwt.GetUsernameFromReq(r); err != nil {
+		response.Err(w, err)
+		return
+	}
+	comms, count, err := c.articleService.GetComments(r.Context(), mux.Vars(r)["slug"], args)
 	if err != nil {
 		response.Err(w, err)
 		return
 	}
+
+	var next *string
+	if len(comms) == args.Limit {
+		last := comms[len(comms)-1]
+		cursor := utils.EncodeCursor(last.CreatedAt, last.ID)
+		next = &cursor
+	}
 	response.Ok(w, response.M{
-		"comments": comms,
+		"comments":      comms,
+		"commentsCount": count,
+		"nextCursor":    next,
 	})
 }
+
+func getCommentQueryParams(q url.Values) (*model.FindCommentsArgs, *model.ConduitError) {
+	var err error
+	depth, limit, cursor := q.Get("depth"), q.Get("limit"), q.Get("cursor")
+	args := &model.FindCommentsArgs{Format: q.Get("format")}
+
+	if depth == "" {
+		// Default if not specified
+		depth = "3"
+	}
+	if args.Depth, err = strconv.Atoi(depth); err != nil {
+		return nil, conduit.BuildError(400, err)
+	}
+
+	if limit == "" {
+		// Default if not specified
+		limit = "20"
+	}
+	if args.Limit, err = strconv.Atoi(limit); err != nil {
+		return nil, conduit.BuildError(400, err)
+	}
+
+	if cursor != "" {
+		if args.CursorTime, args.CursorID, err = utils.DecodeCursor(cursor); err != nil {
+			return nil, conduit.BuildError(400, err)
+		}
+		args.HasCursor = true
+	}
+
+	v := validator.New()
+	if err = v.Struct(args); err != nil {
+		return nil, conduit.BuildError(http.StatusUnprocessableEntity, err)
+	}
+	return args, nil
+}
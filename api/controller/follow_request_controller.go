@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/gorilla/mux"
+)
+
+func (c *UserController) GetIncomingFollowRequests(w http.ResponseWriter, r *http.Request) {
+	args, err := getFollowsQueryParams(r)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	args.Username = jwt.CurrentUser(r)
+	args.Viewer = args.Username
+	profiles, count, err := c.userService.GetIncomingFollowRequests(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profiles":      profiles,
+		"profilesCount": count,
+	})
+}
+
+func (c *UserController) GetOutgoingFollowRequests(w http.ResponseWriter, r *http.Request) {
+	args, err := getFollowsQueryParams(r)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	args.Username = jwt.CurrentUser(r)
+	profiles, count, err := c.userService.GetOutgoingFollowRequests(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profiles":      profiles,
+		"profilesCount": count,
+	})
+}
+
+func (c *UserController) ApproveFollowRequest(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	profile, err := c.userService.ApproveFollowRequest(r.Context(), iu, mux.Vars(r)["username"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profile": profile,
+	})
+}
+
+func (c *UserController) RejectFollowRequest(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	profile, err := c.userService.RejectFollowRequest(r.Context(), iu, mux.Vars(r)["username"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profile": profile,
+	})
+}
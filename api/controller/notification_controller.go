@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/service"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type NotificationController struct {
+	notificationService *service.NotificationService
+}
+
+func NewNotificationController(s *service.Service) *NotificationController {
+	return &NotificationController{s.NotificationService}
+}
+
+func (c *NotificationController) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	args, err := getNotificationQueryParams(r.URL.Query())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	args.Username = jwt.CurrentUser(r)
+	notifications, unread, err := c.notificationService.GetNotifications(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"notifications":      notifications,
+		"notificationsCount": len(notifications),
+		"unreadCount":        unread,
+	})
+}
+
+func (c *NotificationController) MarkRead(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	if err := c.notificationService.MarkRead(r.Context(), iu, mux.Vars(r)["id"]); err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, nil)
+}
+
+func (c *NotificationController) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	if err := c.notificationService.MarkAllRead(r.Context(), iu); err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, nil)
+}
+
+func getNotificationQueryParams(q url.Values) (*model.FindNotificationsArgs, *model.ConduitError) {
+	var err error
+	limit, offset := q.Get("limit"), q.Get("offset")
+	args := &model.FindNotificationsArgs{}
+
+	if limit == "" {
+		// Default if not specified
+		limit = "20"
+	}
+	if args.Limit, err = strconv.Atoi(limit); err != nil {
+		return nil, conduit.BuildError(400, err)
+	}
+
+	if offset == "" {
+		// Default if not specified
+		offset = "0"
+	}
+	if args.Offset, err = strconv.Atoi(offset); err != nil {
+		return nil, conduit.BuildError(400, err)
+	}
+
+	v := validator.New()
+	if err = v.Struct(args); err != nil {
+		return nil, conduit.BuildError(http.StatusUnprocessableEntity, err)
+	}
+	return args, nil
+}
@@ -2,10 +2,14 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
 	"github.com/ashalfarhan/realworld/service"
 	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 )
 
@@ -53,3 +57,124 @@ func (c *ProfileController) GetProfile(w http.ResponseWriter, r *http.Request) {
 		"profile": profile,
 	})
 }
+
+func (c *ProfileController) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	args, err := getFollowsQueryParams(r)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	profiles, count, err := c.userService.GetFollowers(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profiles":      profiles,
+		"profilesCount": count,
+	})
+}
+
+func (c *ProfileController) GetFollowings(w http.ResponseWriter, r *http.Request) {
+	args, err := getFollowsQueryParams(r)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	profiles, count, err := c.userService.GetFollowings(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profiles":      profiles,
+		"profilesCount": count,
+	})
+}
+
+func getFollowsQueryParams(r *http.Request) (*model.FindFollowsArgs, *model.ConduitError) {
+	viewer, cErr := jwt.GetUsernameFromReq(r)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	var err error
+	q := r.URL.Query()
+	limit, offset := q.Get("limit"), q.Get("offset")
+	args := &model.FindFollowsArgs{
+		Username: mux.Vars(r)["username"],
+		Viewer:   viewer,
+	}
+
+	if limit == "" {
+		// Default if not specified
+		limit = "20"
+	}
+	if args.Limit, err = strconv.Atoi(limit); err != nil {
+		return nil, conduit.BuildError(400, err)
+	}
+
+	if offset == "" {
+		// Default if not specified
+		offset = "0"
+	}
+	if args.Offset, err = strconv.Atoi(offset); err != nil {
+		return nil, conduit.BuildError(400, err)
+	}
+
+	v := validator.New()
+	if err = v.Struct(args); err != nil {
+		return nil, conduit.BuildError(http.StatusUnprocessableEntity, err)
+	}
+	return args, nil
+}
+
+func (c *ProfileController) BlockUser(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	profile, err := c.userService.BlockUser(r.Context(), iu, mux.Vars(r)["username"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profile": profile,
+	})
+}
+
+func (c *ProfileController) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	profile, err := c.userService.UnblockUser(r.Context(), iu, mux.Vars(r)["username"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profile": profile,
+	})
+}
+
+func (c *ProfileController) MuteUser(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	profile, err := c.userService.MuteUser(r.Context(), iu, mux.Vars(r)["username"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profile": profile,
+	})
+}
+
+func (c *ProfileController) UnmuteUser(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	profile, err := c.userService.UnmuteUser(r.Context(), iu, mux.Vars(r)["username"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"profile": profile,
+	})
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/gorilla/mux"
+)
+
+func (c *ArticleController) ReactToArticle(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	vars := mux.Vars(r)
+	a, err := c.articleService.ReactToArticle(r.Context(), iu, vars["slug"], vars["reaction"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, response.M{
+		"article": a.Serialize(),
+	})
+}
+
+func (c *ArticleController) UnreactToArticle(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	vars := mux.Vars(r)
+	a, err := c.articleService.UnreactToArticle(r.Context(), iu, vars["slug"], vars["reaction"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, response.M{
+		"article": a.Serialize(),
+	})
+}
+
+func (c *ArticleController) ReactToComment(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	vars := mux.Vars(r)
+	comm, err := c.articleService.ReactToComment(r.Context(), iu, vars["slug"], vars["id"], vars["reaction"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, response.M{
+		"reactions":       comm.Reactions,
+		"viewerReactions": comm.ViewerReactions,
+	})
+}
+
+func (c *ArticleController) UnreactToComment(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	vars := mux.Vars(r)
+	comm, err := c.articleService.UnreactToComment(r.Context(), iu, vars["slug"], vars["id"], vars["reaction"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, response.M{
+		"reactions":       comm.Reactions,
+		"viewerReactions": comm.ViewerReactions,
+	})
+}
+
+func (c *ArticleController) GetReactions(w http.ResponseWriter, r *http.Request) {
+	response.Ok(w, response.M{
+		"reactions": config.Reactions,
+	})
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/service"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+// Keeps idle connections open through proxies dropping silent ones,
+// the followings are read again at the same pace
+const streamHeartbeat = 25 * time.Second
+
+// How many event IDs are remembered to skip the copies from other channels,
+// the copies of an event arrive close together
+const streamSeenEvents = 64
+
+type StreamController struct {
+	eventService   *service.EventService
+	articleService *service.ArticleService
+}
+
+func NewStreamController(s *service.Service) *StreamController {
+	return &StreamController{s.EventService, s.ArticleService}
+}
+
+// Push the notifications, the new articles of followed authors and tags
+// and, with ?article=slug, the new comments on that article as Server-Sent Events.
+// Authors and tags followed or unfollowed while connected are picked up
+// within a heartbeat.
+func (c *StreamController) Stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		response.InternalError(w)
+		return
+	}
+
+	ctx := r.Context()
+	iu := jwt.CurrentUser(r)
+	var articleID string
+	if slug := r.URL.Query().Get("article"); slug != "" {
+		a, err := c.articleService.GetArticleBySlug(ctx, iu, slug)
+		if err != nil {
+			response.Err(w, err)
+			return
+		}
+		articleID = a.ID
+	}
+
+	events, cancel, err := c.eventService.Subscribe(ctx, iu, articleID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	log := logger.GetCtx(ctx)
+	seen := newSeenEvents(streamSeenEvents)
+	ticker := time.NewTicker(streamHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.eventService.Resubscribe(ctx, events, iu, articleID)
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if seen.Check(e.ID) || !c.eventService.IsVisible(ctx, iu, e) {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				log.Warnf("Cannot write %q event, reason: %v", e.Type, err)
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e *model.Event) error {
+	data, err := e.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
+
+// The IDs of the latest events, the oldest is forgotten first
+type seenEvents struct {
+	ids  map[string]bool
+	ring []string
+	next int
+}
+
+func newSeenEvents(size int) *seenEvents {
+	return &seenEvents{ids: make(map[string]bool, size), ring: make([]string, size)}
+}
+
+// Reports whether the event was seen before, and remembers it
+func (s *seenEvents) Check(id string) bool {
+	if id == "" {
+		return false
+	}
+	if s.ids[id] {
+		return true
+	}
+	delete(s.ids, s.ring[s.next])
+	s.ring[s.next] = id
+	s.ids[id] = true
+	s.next = (s.next + 1) % len(s.ring)
+	return false
+}
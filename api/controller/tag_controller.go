@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// Without `sort` the response stays a plain list of tag names (as the spec expects),
+// otherwise the tags are returned with their metadata and usage count.
+func (c *ArticleController) GetAllTags(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("sort") == "" {
+		tags, err := c.articleService.GetAllTags(r.Context())
+		if err != nil {
+			response.Err(w, err)
+			return
+		}
+		response.Ok(w, response.M{
+			"tags": tags,
+		})
+		return
+	}
+
+	args, err := getTagQueryParams(q)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	if args.Username, err = jwt.GetUsernameFromReq(r); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	tags, err := c.articleService.GetTags(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"tags": tags,
+	})
+}
+
+func (c *ArticleController) GetTag(w http.ResponseWriter, r *http.Request) {
+	args, err := getArticleQueryParams(r.URL.Query())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	username, err := jwt.GetUsernameFromReq(r)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	tag, err := c.articleService.GetTag(r.Context(), username, mux.Vars(r)["name"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	args.Tag = tag.Name
+	args.Username = username
+	articles, err := c.articleService.GetArticles(r.Context(), args)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"tag":           tag,
+		"articles":      articles.SerializeFormat(args.Format),
+		"articlesCount": len(articles),
+	})
+}
+
+func (c *ArticleController) FollowTag(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	tag, err := c.articleService.FollowTag(r.Context(), iu, mux.Vars(r)["name"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"tag": tag,
+	})
+}
+
+func (c *ArticleController) UnfollowTag(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	tag, err := c.articleService.UnfollowTag(r.Context(), iu, mux.Vars(r)["name"])
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Ok(w, response.M{
+		"tag": tag,
+	})
+}
+
+func getTagQueryParams(q url.Values) (*model.FindTagsArgs, *model.ConduitError) {
+	var err error
+	limit := q.Get("limit")
+	args := &model.FindTagsArgs{
+		Sort: q.Get("sort"),
+	}
+
+	if limit == "" {
+		// Default if not specified
+		limit = "20"
+	}
+	if args.Limit, err = strconv.Atoi(limit); err != nil {
+		return nil, conduit.BuildError(400, err)
+	}
+
+	v := validator.New()
+	if err = v.Struct(args); err != nil {
+		return nil, conduit.BuildError(http.StatusUnprocessableEntity, err)
+	}
+	return args, nil
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/service"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// Room for the multipart framing and the other fields around the file
+const multipartOverhead = 1 << 20
+
+var errNoUploadFile = errors.New("the image must be sent in the \"file\" field")
+
+type UploadController struct {
+	uploadService *service.UploadService
+}
+
+func NewUploadController(s *service.Service) *UploadController {
+	return &UploadController{s.UploadService}
+}
+
+// Expects a multipart form with the image in "file"
+// and optionally "purpose", either "article" (default) or "avatar".
+func (c *UploadController) Upload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, config.UploadMaxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartOverhead); err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			response.Err(w, conduit.BuildError(http.StatusRequestEntityTooLarge, service.ErrUploadTooLarge))
+			return
+		}
+		response.ClientError(w, err)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		response.ClientError(w, errNoUploadFile)
+		return
+	}
+	defer file.Close()
+
+	d := &model.CreateUploadFields{Purpose: r.FormValue("purpose")}
+	if d.Purpose == "" {
+		// Default if not specified
+		d.Purpose = model.UploadArticle
+	}
+	v := validator.New()
+	if err := v.Struct(d); err != nil {
+		response.Err(w, conduit.BuildError(http.StatusUnprocessableEntity, err))
+		return
+	}
+
+	iu := jwt.CurrentUser(r)
+	u, sErr := c.uploadService.Upload(r.Context(), iu, d, file)
+	if sErr != nil {
+		response.Err(w, sErr)
+		return
+	}
+	response.Created(w, response.M{
+		"upload": u,
+	})
+}
+
+func (c *UploadController) DeleteUpload(w http.ResponseWriter, r *http.Request) {
+	iu := jwt.CurrentUser(r)
+	if err := c.uploadService.DeleteUpload(r.Context(), iu, mux.Vars(r)["id"]); err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Accepted(w, nil)
+}
@@ -27,7 +27,7 @@ func (c *UserController) GetCurrentUser(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	res := u.Serialize(jwt.GetToken(r))
-	response.Ok(w, response.M{
+	response.Cached(w, r, &response.Validator{LastModified: u.UpdatedAt, Version: u.Version}, response.M{
 		"user": res,
 	})
 }
@@ -38,6 +38,7 @@ func (c *UserController) UpdateCurrentUser(w http.ResponseWriter, r *http.Reques
 		response.Err(w, err)
 		return
 	}
+	req.User.IfMatch = response.IfMatchVersions(r)
 	iu := jwt.CurrentUser(r)
 	u, err := c.userService.Update(r.Context(), req.User, iu)
 	if err != nil {
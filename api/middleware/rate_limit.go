@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashalfarhan/realworld/api/response"
+	"github.com/ashalfarhan/realworld/cache/store"
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/utils/jwt"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+// Each limiter counts under its own name, so a request can be held to
+// the general limit and to a stricter one of its route at the same time.
+type RateLimiter struct {
+	store  store.RateStore
+	name   string
+	limit  int
+	window time.Duration
+}
+
+func NewRateLimiter(s store.RateStore, name string, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{s, name, limit, window}
+}
+
+// A limit of zero lets every request through
+func (l *RateLimiter) Limit(next http.HandlerFunc) http.HandlerFunc {
+	if l.limit <= 0 {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := l.store.Allow(r.Context(), l.name+":"+rateLimitKey(r), l.limit, l.window)
+		if err != nil {
+			// Better to serve everyone than no one
+			logger.GetCtx(r.Context()).Warnf("Cannot rate limit %q, reason: %v", l.name, err)
+			next(w, r)
+			return
+		}
+		setRateLimitHeaders(w, res)
+		if !res.Allowed {
+			response.TooManyRequests(w, res.Reset)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return l.Limit(next.ServeHTTP)
+}
+
+// Signed in users are counted wherever they come from, anyone else by address
+func rateLimitKey(r *http.Request) string {
+	username := jwt.CurrentUser(r)
+	if username == "" {
+		username, _ = jwt.GetUsernameFromReq(r)
+	}
+	if username != "" {
+		return "user:" + username
+	}
+	return "ip:" + clientIP(r)
+}
+
+func clientIP(r *http.Request) string {
+	if config.TrustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			hops := strings.Split(fwd, ",")
+			return strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// When several limits apply, the headers tell about the one closest to running out
+func setRateLimitHeaders(w http.ResponseWriter, res *store.RateLimit) {
+	h := w.Header()
+	if prev, err := strconv.Atoi(h.Get("RateLimit-Remaining")); err == nil && prev < res.Remaining {
+		return
+	}
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(res.Reset.Seconds()))))
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// EventSource cannot set headers, so streaming clients pass their token in "?token=".
+// Only use it on routes where the URL does not end up in shared logs or caches.
+func TokenFromQuery(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("token"); token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Token "+token)
+		}
+		next(w, r)
+	}
+}
+
+// Replaces the server WriteTimeout, which would also cut long-lived streams
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.TimeoutHandler(next, d, "")
+	}
+}
@@ -0,0 +1,114 @@
+package response
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// What a cacheable response is validated against
+type Validator struct {
+	// When the resource last changed, sent as Last-Modified
+	LastModified time.Time
+	// Lists only promise an equivalent page, not the same bytes
+	Weak bool
+	// Leads the ETag when set, so If-Match on an update can name it
+	Version int
+}
+
+// Like Ok, but lets clients revalidate with If-None-Match and answers 304
+// when nothing changed. The ETag hashes the body along with the modification
+// time, counters and viewer state are not covered by the time alone.
+// Signed in responses carry viewer state, so only the client may keep them.
+func Cached(w http.ResponseWriter, r *http.Request, v *Validator, data interface{}) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		logrus.Printf("Failed to encode json response of %v, Error: %v\n", data, err)
+		InternalError(w)
+		return
+	}
+
+	h := w.Header()
+	etag := entityTag(v, buf.Bytes())
+	h.Set("ETag", etag)
+	h.Add("Vary", "Authorization")
+	if r.Header.Get("Authorization") != "" {
+		h.Set("Cache-Control", "private, no-cache")
+	} else {
+		h.Set("Cache-Control", "public, no-cache")
+	}
+	if !v.LastModified.IsZero() {
+		h.Set("Last-Modified", v.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if noneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func entityTag(v *Validator, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(v.LastModified.UTC().Format(time.RFC3339Nano)))
+	sum.Write(body)
+	tag := base64.RawURLEncoding.EncodeToString(sum.Sum(nil)[:16])
+	if v.Version > 0 {
+		tag = strconv.Itoa(v.Version) + "." + tag
+	}
+	tag = `"` + tag + `"`
+	if v.Weak {
+		return "W/" + tag
+	}
+	return tag
+}
+
+// The versions named by If-Match, nil when the header is not set or is "*".
+// Only the version of a tag is compared, changes to counters or viewer state
+// alter the body but do not conflict with an update.
+// Weak tags and tags without a version are left out, so they never match.
+func IfMatchVersions(r *http.Request) []int {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil
+	}
+	versions := []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil
+		}
+		if !strings.HasPrefix(tag, `"`) {
+			continue
+		}
+		// The hash is unpadded base64url, which has no dot
+		version := strings.SplitN(strings.Trim(tag, `"`), ".", 2)[0]
+		if n, err := strconv.Atoi(version); err == nil {
+			versions = append(versions, n)
+		}
+	}
+	return versions
+}
+
+// If-None-Match uses the weak comparison, a weak tag matches its strong form
+func noneMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
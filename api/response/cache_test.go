@@ -0,0 +1,84 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCached(t *testing.T) {
+	as := assert.New(t)
+	updated := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	v := &Validator{LastModified: updated}
+	body := M{"article": M{"slug": "slug"}}
+
+	rec := httptest.NewRecorder()
+	Cached(rec, httptest.NewRequest(http.MethodGet, "/api/articles/slug", nil), v, body)
+	as.Equal(http.StatusOK, rec.Code)
+	as.JSONEq(`{"article":{"slug":"slug"}}`, rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	as.False(strings.HasPrefix(etag, "W/"), "Single resources should have a strong ETag")
+	as.Equal("Sun, 02 Jan 2022 03:04:05 GMT", rec.Header().Get("Last-Modified"))
+	as.Equal("public, no-cache", rec.Header().Get("Cache-Control"))
+	as.Equal("Authorization", rec.Header().Get("Vary"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/articles/slug", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	rec = httptest.NewRecorder()
+	Cached(rec, req, v, body)
+	as.Equal(http.StatusNotModified, rec.Code)
+	as.Empty(rec.Body.String())
+	as.Equal(etag, rec.Header().Get("ETag"))
+
+	rec = httptest.NewRecorder()
+	Cached(rec, req, v, M{"article": M{"slug": "slug", "favoritesCount": 1}})
+	as.Equal(http.StatusOK, rec.Code, "A changed body should not match, even at the same modification time")
+	as.NotEqual(etag, rec.Header().Get("ETag"))
+}
+
+func TestCachedList(t *testing.T) {
+	as := assert.New(t)
+	v := &Validator{Weak: true}
+	body := M{"articles": []M{}, "articlesCount": 0}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/articles", nil)
+	req.Header.Set("Authorization", "Token token")
+	rec := httptest.NewRecorder()
+	Cached(rec, req, v, body)
+	etag := rec.Header().Get("ETag")
+	as.True(strings.HasPrefix(etag, `W/"`), "Lists should have a weak ETag")
+	as.Equal("private, no-cache", rec.Header().Get("Cache-Control"))
+	as.Empty(rec.Header().Get("Last-Modified"), "An empty list has no modification time")
+
+	req.Header.Set("If-None-Match", strings.TrimPrefix(etag, "W/"))
+	rec = httptest.NewRecorder()
+	Cached(rec, req, v, body)
+	as.Equal(http.StatusNotModified, rec.Code, "If-None-Match should use the weak comparison")
+}
+
+func TestIfMatchVersions(t *testing.T) {
+	as := assert.New(t)
+
+	rec := httptest.NewRecorder()
+	Cached(rec, httptest.NewRequest(http.MethodGet, "/api/user", nil), &Validator{Version: 3}, M{})
+	etag := rec.Header().Get("ETag")
+	as.True(strings.HasPrefix(etag, `"3.`), "The ETag should lead with the version")
+
+	ifMatch := func(header string) []int {
+		req := httptest.NewRequest(http.MethodPut, "/api/user", nil)
+		if header != "" {
+			req.Header.Set("If-Match", header)
+		}
+		return IfMatchVersions(req)
+	}
+	as.Nil(ifMatch(""))
+	as.Nil(ifMatch("*"), "Any version should do")
+	as.Equal([]int{3}, ifMatch(etag))
+	as.Equal([]int{2, 3}, ifMatch(`"2", `+etag))
+	as.Equal([]int{}, ifMatch("W/"+etag), "Weak tags should never match")
+	as.Equal([]int{}, ifMatch(`"unversioned"`))
+}
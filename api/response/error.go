@@ -2,8 +2,11 @@ package response
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ashalfarhan/realworld/conduit"
 	"github.com/ashalfarhan/realworld/model"
@@ -36,6 +39,12 @@ func UnauthorizeError(w http.ResponseWriter, reason string) {
 	errorJSON(w, http.StatusUnauthorized, fmt.Errorf("%w: %s", conduit.ErrUnauthorized, reason))
 }
 
+// Retry-After is in whole seconds, rounded up so clients do not come back too early
+func TooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	errorJSON(w, http.StatusTooManyRequests, conduit.ErrRateLimited)
+}
+
 func EntityError(w http.ResponseWriter, err error) {
 	e, ok := err.(validator.ValidationErrors)
 	if !ok {
@@ -1,30 +1,55 @@
 package api
 
 import (
+	"expvar"
 	"net/http"
+	"time"
 
 	"github.com/ashalfarhan/realworld/api/controller"
 	"github.com/ashalfarhan/realworld/api/middleware"
+	"github.com/ashalfarhan/realworld/cache/store"
+	"github.com/ashalfarhan/realworld/config"
 	"github.com/ashalfarhan/realworld/service"
 	"github.com/gorilla/mux"
 )
 
-func InitRoutes(s *service.Service) *mux.Router {
+func InitRoutes(s *service.Service, rates store.RateStore) *mux.Router {
 	r := mux.NewRouter()
 	r.Use(middleware.InjectReqID)
 
 	r.HandleFunc("/", controller.Hello).Methods(http.MethodGet)
+	if config.DebugVars {
+		r.Handle("/debug/vars", expvar.Handler()).Methods(http.MethodGet)
+	}
+
+	// Stream, registered before the "/api" subrouter so it skips its timeout
+	sc := controller.NewStreamController(s)
+	r.HandleFunc("/api/stream", middleware.TokenFromQuery(middleware.WithUser(sc.Stream))).Methods(http.MethodGet)
+
+	// Uploads, the local driver serves them from disk
+	if config.UploadDriver == "local" {
+		files := http.StripPrefix("/uploads/", http.FileServer(http.Dir(config.UploadDir)))
+		r.PathPrefix("/uploads/").Handler(middleware.StaticFiles(files)).Methods(http.MethodGet)
+	}
+
 	apiRoute := r.PathPrefix("/api").Subrouter()
+	apiRoute.Use(middleware.Timeout(5 * time.Second))
+	apiRoute.Use(middleware.NewRateLimiter(rates, "api", config.RateLimitGeneral, config.RateLimitWindow).Middleware)
 
-	// Auth
+	// Auth, guessing passwords and mass sign ups are held to a stricter limit
 	auth := controller.NewAuthController(s)
-	apiRoute.HandleFunc("/users", auth.RegisterUser).Methods(http.MethodPost)
-	apiRoute.HandleFunc("/users/login", auth.LoginUser).Methods(http.MethodPost)
+	authLimit := middleware.NewRateLimiter(rates, "auth", config.RateLimitAuth, config.RateLimitWindow)
+	apiRoute.HandleFunc("/users", authLimit.Limit(auth.RegisterUser)).Methods(http.MethodPost)
+	apiRoute.HandleFunc("/users/login", authLimit.Limit(auth.LoginUser)).Methods(http.MethodPost)
 
 	// User
 	uc := controller.NewUserController(s)
 	apiRoute.HandleFunc("/user", middleware.WithUser(uc.GetCurrentUser)).Methods(http.MethodGet)
 	apiRoute.HandleFunc("/user", middleware.WithUser(uc.UpdateCurrentUser)).Methods(http.MethodPut)
+	apiRoute.HandleFunc("/user/follow-requests/incoming", middleware.WithUser(uc.GetIncomingFollowRequests)).Methods(http.MethodGet)
+	apiRoute.HandleFunc("/user/follow-requests/outgoing", middleware.WithUser(uc.GetOutgoingFollowRequests)).Methods(http.MethodGet)
+	apiRoute.HandleFunc("/user/follow-requests/{username}/approve", middleware.WithUser(uc.ApproveFollowRequest)).Methods(http.MethodPost)
+	apiRoute.HandleFunc("/user/follow-requests/{username}", middleware.WithUser(uc.RejectFollowRequest)).Methods(http.MethodDelete)
 
 	// Profile
 	pc := controller.NewProfileController(s)
@@ -32,10 +57,35 @@ func InitRoutes(s *service.Service) *mux.Router {
 	profileRoute.HandleFunc("/{username}", middleware.WithUser(pc.GetProfile)).Methods(http.MethodGet)
 	profileRoute.HandleFunc("/{username}/follow", middleware.WithUser(pc.FollowUser)).Methods(http.MethodPost)
 	profileRoute.HandleFunc("/{username}/follow", middleware.WithUser(pc.UnfollowUser)).Methods(http.MethodDelete)
+	profileRoute.HandleFunc("/{username}/block", middleware.WithUser(pc.BlockUser)).Methods(http.MethodPost)
+	profileRoute.HandleFunc("/{username}/block", middleware.WithUser(pc.UnblockUser)).Methods(http.MethodDelete)
+	profileRoute.HandleFunc("/{username}/mute", middleware.WithUser(pc.MuteUser)).Methods(http.MethodPost)
+	profileRoute.HandleFunc("/{username}/mute", middleware.WithUser(pc.UnmuteUser)).Methods(http.MethodDelete)
+	profileRoute.HandleFunc("/{username}/followers", pc.GetFollowers).Methods(http.MethodGet)
+	profileRoute.HandleFunc("/{username}/following", pc.GetFollowings).Methods(http.MethodGet)
+
+	// Notification
+	nc := controller.NewNotificationController(s)
+	apiRoute.HandleFunc("/notifications", middleware.WithUser(nc.GetNotifications)).Methods(http.MethodGet)
+	apiRoute.HandleFunc("/notifications/read", middleware.WithUser(nc.MarkAllRead)).Methods(http.MethodPost)
+	apiRoute.HandleFunc("/notifications/{id}/read", middleware.WithUser(nc.MarkRead)).Methods(http.MethodPost)
+
+	// Upload
+	upc := controller.NewUploadController(s)
+	apiRoute.HandleFunc("/uploads", middleware.WithUser(upc.Upload)).Methods(http.MethodPost)
+	apiRoute.HandleFunc("/uploads/{id}", middleware.WithUser(upc.DeleteUpload)).Methods(http.MethodDelete)
 
 	// Article
 	ac := controller.NewArticleController(s)
+	commentLimit := middleware.NewRateLimiter(rates, "comments", config.RateLimitComments, config.RateLimitWindow)
+	apiRoute.HandleFunc("/reactions", ac.GetReactions).Methods(http.MethodGet)
 	apiRoute.HandleFunc("/tags", ac.GetAllTags).Methods(http.MethodGet)
+	apiRoute.HandleFunc("/tags/{name}", ac.GetTag).Methods(http.MethodGet)
+	apiRoute.HandleFunc("/tags/{name}/follow", middleware.WithUser(ac.FollowTag)).Methods(http.MethodPost)
+	apiRoute.HandleFunc("/tags/{name}/follow", middleware.WithUser(ac.UnfollowTag)).Methods(http.MethodDelete)
+	apiRoute.HandleFunc("/user/bookmarks", middleware.WithUser(ac.GetBookmarks)).Methods(http.MethodGet)
+	apiRoute.HandleFunc("/user/bookmarks/collections", middleware.WithUser(ac.GetBookmarkCollections)).Methods(http.MethodGet)
+	apiRoute.HandleFunc("/user/bookmarks/collections/{name}", middleware.WithUser(ac.DeleteBookmarkCollection)).Methods(http.MethodDelete)
 	apiRoute.HandleFunc("/articles", ac.GetFiltered).Methods(http.MethodGet)
 	apiRoute.HandleFunc("/articles", middleware.WithUser(ac.CreateArticle)).Methods(http.MethodPost)
 	articleRoute := apiRoute.PathPrefix("/articles").Subrouter()
@@ -45,9 +95,17 @@ func InitRoutes(s *service.Service) *mux.Router {
 	articleRoute.HandleFunc("/{slug}", middleware.WithUser(ac.UpdateArticle)).Methods(http.MethodPut)
 	articleRoute.HandleFunc("/{slug}/favorite", middleware.WithUser(ac.FavoriteArticle)).Methods(http.MethodPost)
 	articleRoute.HandleFunc("/{slug}/favorite", middleware.WithUser(ac.UnFavoriteArticle)).Methods(http.MethodDelete)
+	articleRoute.HandleFunc("/{slug}/bookmark", middleware.WithUser(ac.BookmarkArticle)).Methods(http.MethodPost)
+	articleRoute.HandleFunc("/{slug}/bookmark", middleware.WithUser(ac.UnbookmarkArticle)).Methods(http.MethodDelete)
+	articleRoute.HandleFunc("/{slug}/reactions/{reaction}", middleware.WithUser(ac.ReactToArticle)).Methods(http.MethodPost)
+	articleRoute.HandleFunc("/{slug}/reactions/{reaction}", middleware.WithUser(ac.UnreactToArticle)).Methods(http.MethodDelete)
 	articleRoute.HandleFunc("/{slug}/comments", ac.GetArticleComments).Methods(http.MethodGet)
-	articleRoute.HandleFunc("/{slug}/comments", middleware.WithUser(ac.CreateComment)).Methods(http.MethodPost)
+	articleRoute.HandleFunc("/{slug}/comments", middleware.WithUser(commentLimit.Limit(ac.CreateComment))).Methods(http.MethodPost)
+	articleRoute.HandleFunc("/{slug}/comments/{id}", middleware.WithUser(ac.UpdateComment)).Methods(http.MethodPut)
 	articleRoute.HandleFunc("/{slug}/comments/{id}", middleware.WithUser(ac.DeleteComment)).Methods(http.MethodDelete)
+	articleRoute.HandleFunc("/{slug}/comments/{id}/edits", middleware.WithUser(ac.GetCommentEdits)).Methods(http.MethodGet)
+	articleRoute.HandleFunc("/{slug}/comments/{id}/reactions/{reaction}", middleware.WithUser(ac.ReactToComment)).Methods(http.MethodPost)
+	articleRoute.HandleFunc("/{slug}/comments/{id}/reactions/{reaction}", middleware.WithUser(ac.UnreactToComment)).Methods(http.MethodDelete)
 
 	return r
 }
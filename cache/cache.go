@@ -4,25 +4,64 @@ import (
 	"context"
 	"time"
 
+	"github.com/ashalfarhan/realworld/cache/store"
 	"github.com/ashalfarhan/realworld/config"
 	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 )
 
-func Init() *redis.Client {
-	Ca := redis.NewClient(&redis.Options{
-		Addr:     "localhost:6379",
-		Password: config.RedisPass,
-		DB:       0,
-	})
+// The stores of the configured driver, and the connection behind them if any
+type Cache struct {
+	Store  *store.CacheStore
+	client redis.UniversalClient
+}
+
+func Init() *Cache {
+	switch config.CacheDriver {
+	case "redis":
+		return initRedis()
+	case "memory":
+		logrus.Printf("Using in-memory cache of %d entries", config.CacheMaxEntries)
+		return &Cache{Store: store.NewMemoryStore(config.CacheMaxEntries)}
+	case "none":
+		logrus.Println("Running without cache")
+		return &Cache{Store: store.NewNoopStore()}
+	default:
+		logrus.Panicf("Unknown cache driver %q", config.CacheDriver)
+		return nil
+	}
+}
+
+// An unreachable redis only costs performance, so the app runs without a cache instead
+func initRedis() *Cache {
+	opt, err := redisOptions()
+	if err != nil {
+		logrus.Panicln("Cannot configure Redis:", err)
+	}
+	Ca := redis.NewUniversalClient(opt)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if _, err := Ca.Ping(ctx).Result(); err != nil {
-		defer Ca.Close()
-		logrus.Panicf("Cannot Ping Redis, Reason: %v", err)
-		return nil
+		Ca.Close()
+		logrus.Warnf("Cannot Ping Redis, running without cache. Reason: %v", err)
+		return &Cache{Store: store.NewNoopStore()}
 	}
 	logrus.Println("Successfully initialize redis cache")
-	return Ca
+	s := store.NewCacheStore(Ca)
+	if config.CacheLocalTTL > 0 {
+		logrus.Printf("Keeping articles in process for %v", config.CacheLocalTTL)
+		s.ArticleStore = store.NewTieredArticleStore(Ca, s.ArticleStore, config.CacheMaxEntries, config.CacheLocalTTL)
+	}
+	return &Cache{Store: s, client: Ca}
+}
+
+func (c *Cache) Close() error {
+	if c.client == nil {
+		return nil
+	}
+	if err := c.client.Close(); err != nil && err != redis.ErrClosed {
+		return err
+	}
+	return nil
 }
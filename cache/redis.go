@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/go-redis/redis/v8"
+)
+
+// The client kind follows the options: a master name makes a sentinel
+// backed failover client, several addresses a cluster client.
+func redisOptions() (*redis.UniversalOptions, error) {
+	opt, err := redis.ParseURL(config.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+	if config.RedisPass != "" {
+		opt.Password = config.RedisPass
+	}
+
+	addrs := []string{opt.Addr}
+	if len(config.RedisAddrs) > 0 {
+		addrs = config.RedisAddrs
+		// Every node is verified against its own address instead of the one of the URL
+		if opt.TLSConfig != nil {
+			opt.TLSConfig.ServerName = ""
+		}
+	}
+	if len(addrs) > 1 && config.RedisMasterName == "" && opt.DB != 0 {
+		return nil, fmt.Errorf("redis cluster only has DB 0, got %d", opt.DB)
+	}
+
+	return &redis.UniversalOptions{
+		Addrs:        addrs,
+		MasterName:   config.RedisMasterName,
+		DB:           opt.DB,
+		Username:     opt.Username,
+		Password:     opt.Password,
+		TLSConfig:    opt.TLSConfig,
+		PoolSize:     config.RedisPoolSize,
+		DialTimeout:  config.RedisDialTimeout,
+		ReadTimeout:  config.RedisReadTimeout,
+		WriteTimeout: config.RedisWriteTimeout,
+	}, nil
+}
@@ -3,6 +3,8 @@ package store
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/ashalfarhan/realworld/config"
 	"github.com/ashalfarhan/realworld/model"
@@ -10,26 +12,129 @@ import (
 )
 
 type ArticleStoreImpl struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
+// Articles are cached once for every viewer, the viewer specific fields
+// are overlaid from the ViewerStore.
+//
+// FindOneBySlug also returns the version it read, nil and the version on a miss.
+// An article loaded afterwards is saved under that version, and is dropped
+// when the version has moved on since, as the load may predate the change.
 type ArticleStore interface {
-	FindOneBySlug(context.Context, string, string) *model.Article
+	FindOneBySlug(context.Context, string) (*model.Article, string)
 	SaveBySlug(context.Context, string, string, *model.Article)
+	InvalidateBySlug(context.Context, string)
+	IncrFavoritesCount(context.Context, string, int64)
 }
 
 var prefix = "articles"
 
-func (s *ArticleStoreImpl) FindOneBySlug(ctx context.Context, slug, userID string) *model.Article {
-	key := fmt.Sprintf("%s|slug:%s|user_id:%s", prefix, slug, userID)
+// Every cached article embeds the current version of its slug,
+// so bumping the version drops it and leaves it to expire.
+// The keys of an article share a hash tag, keeping them on one cluster node.
+func versionKey(slug string) string {
+	return fmt.Sprintf("%s|{slug:%s}|version", prefix, slug)
+}
+
+func articleKey(slug, version string) string {
+	return fmt.Sprintf("%s|{slug:%s}|v:%s", prefix, slug, version)
+}
+
+// Kept up to date instead of invalidated, until the version moves on
+func favoritesCountKey(slug, version string) string {
+	return fmt.Sprintf("%s|{slug:%s}|v:%s|favorites_count", prefix, slug, version)
+}
+
+// The version a missing key stands for
+const initialVersion = "0"
+
+// Seeds the entry only when the version is still the one read before the load
+var saveIfCurrent = redis.NewScript(`
+if (redis.call("GET", KEYS[1]) or ARGV[1]) ~= ARGV[2] then
+	return false
+end
+redis.call("SET", KEYS[2], ARGV[3], "PX", ARGV[5])
+redis.call("SET", KEYS[3], ARGV[4], "PX", ARGV[5], "NX")
+return true`)
+
+// Only a seeded counter of the current version is touched, one created from
+// nothing would be wrong. Otherwise the version moves on, so a load that
+// began before the change cannot seed a count without it.
+var incrOrInvalidate = redis.NewScript(`
+if (redis.call("GET", KEYS[1]) or ARGV[1]) == ARGV[2] and redis.call("EXISTS", KEYS[2]) == 1 then
+	return redis.call("INCRBY", KEYS[2], ARGV[3])
+end
+redis.call("SET", KEYS[1], ARGV[4], "PX", ARGV[5])
+return false`)
+
+func newVersion() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+func (s *ArticleStoreImpl) version(ctx context.Context, slug string) (string, error) {
+	v, err := s.client.Get(ctx, versionKey(slug)).Result()
+	if err == redis.Nil {
+		return initialVersion, nil
+	}
+	return v, err
+}
+
+// Without a version nothing can be saved, the empty one never matches
+func (s *ArticleStoreImpl) FindOneBySlug(ctx context.Context, slug string) (*model.Article, string) {
+	v, err := s.version(ctx, slug)
+	if err != nil {
+		return nil, ""
+	}
+	pipe := s.client.Pipeline()
+	get := pipe.Get(ctx, articleKey(slug, v))
+	count := pipe.Get(ctx, favoritesCountKey(slug, v))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, v
+	}
+
 	res := new(model.Article)
-	if err := s.client.Get(ctx, key).Scan(res); err != nil {
-		return nil
+	if err := get.Scan(res); err != nil {
+		return nil, v
+	}
+	if res.FavoritesCount, err = count.Int(); err != nil {
+		return nil, v
+	}
+	return res, v
+}
+
+func (s *ArticleStoreImpl) SaveBySlug(ctx context.Context, slug, version string, a *model.Article) {
+	data, err := sharedArticle(a).MarshalBinary()
+	if err != nil || version == "" {
+		return
 	}
-	return res
+	keys := []string{versionKey(slug), articleKey(slug, version), favoritesCountKey(slug, version)}
+	saveIfCurrent.Run(ctx, s.client, keys, initialVersion, version, data, a.FavoritesCount, config.CacheTTL.Milliseconds())
 }
 
-func (s *ArticleStoreImpl) SaveBySlug(ctx context.Context, slug, userID string, a *model.Article) {
-	key := fmt.Sprintf("%s|slug:%s|user_id:%s", prefix, slug, userID)
-	s.client.SetEX(ctx, key, a, config.CacheTTL)
+// The version is never reused, and it only has to outlive the entry
+// written under the previous one, which expires within a CacheTTL.
+func (s *ArticleStoreImpl) InvalidateBySlug(ctx context.Context, slug string) {
+	s.client.Set(ctx, versionKey(slug), newVersion(), config.CacheTTL)
+}
+
+// A copy of "a" without the fields of whoever it was loaded for
+func sharedArticle(a *model.Article) *model.Article {
+	shared := *a
+	shared.Favorited, shared.Bookmarked, shared.ViewerReactions = false, false, nil
+	if a.Author != nil {
+		author := *a.Author
+		author.Following = false
+		shared.Author = &author
+	}
+	return &shared
+}
+
+func (s *ArticleStoreImpl) IncrFavoritesCount(ctx context.Context, slug string, n int64) {
+	v, err := s.version(ctx, slug)
+	if err != nil {
+		return
+	}
+	keys := []string{versionKey(slug), favoritesCountKey(slug, v)}
+	incrOrInvalidate.Run(ctx, s.client, keys, initialVersion, v, n, newVersion(), config.CacheTTL.Milliseconds())
 }
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	ttl := config.CacheTTL
+	config.CacheTTL = time.Minute
+	t.Cleanup(func() { config.CacheTTL = ttl })
+	return srv, client
+}
+
+// Loads and saves like a reader that missed
+func saveArticle(ctx context.Context, s ArticleStore, slug string, a *model.Article) {
+	_, v := s.FindOneBySlug(ctx, slug)
+	s.SaveBySlug(ctx, slug, v, a)
+}
+
+func findArticle(ctx context.Context, s ArticleStore, slug string) *model.Article {
+	a, _ := s.FindOneBySlug(ctx, slug)
+	return a
+}
+
+func TestArticleStoreSaveAndFind(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	srv, client := newTestClient(t)
+	s := &ArticleStoreImpl{client}
+
+	as.Nil(findArticle(ctx, s, "slug"))
+	saveArticle(ctx, s, "slug", &model.Article{
+		ID:              "article",
+		Favorited:       true,
+		Bookmarked:      true,
+		FavoritesCount:  2,
+		ViewerReactions: []string{"like"},
+		Author:          &model.ProfileRs{Username: "author", Following: true},
+	})
+
+	a := findArticle(ctx, s, "slug")
+	if as.NotNil(a) {
+		as.Equal("article", a.ID)
+		as.Equal(2, a.FavoritesCount)
+		as.False(a.Favorited, "Viewer state should not be shared")
+		as.False(a.Bookmarked, "Viewer state should not be shared")
+		as.False(a.Author.Following, "Viewer state should not be shared")
+		as.Empty(a.ViewerReactions, "Viewer state should not be shared")
+	}
+
+	srv.FastForward(time.Minute)
+	as.Nil(findArticle(ctx, s, "slug"), "Entry should expire after the TTL")
+}
+
+func TestArticleStoreInvalidateBySlug(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	srv, client := newTestClient(t)
+	s := &ArticleStoreImpl{client}
+
+	saveArticle(ctx, s, "slug", &model.Article{ID: "article"})
+	saveArticle(ctx, s, "other", &model.Article{ID: "other"})
+
+	s.InvalidateBySlug(ctx, "slug")
+	as.Nil(findArticle(ctx, s, "slug"))
+	as.NotNil(findArticle(ctx, s, "other"), "Other articles should be kept")
+
+	saveArticle(ctx, s, "slug", &model.Article{ID: "article", Title: "Updated"})
+	if a := findArticle(ctx, s, "slug"); as.NotNil(a) {
+		as.Equal("Updated", a.Title)
+	}
+
+	// Once the version expires the entries written under it are missed, not resurrected
+	srv.FastForward(time.Minute)
+	as.False(srv.Exists(versionKey("slug")))
+	as.Nil(findArticle(ctx, s, "slug"))
+}
+
+func TestArticleStoreFavoritesCount(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	srv, client := newTestClient(t)
+	s := &ArticleStoreImpl{client}
+
+	s.IncrFavoritesCount(ctx, "slug", 1)
+	as.False(srv.Exists(favoritesCountKey("slug", initialVersion)), "An unseeded counter should not be created")
+
+	saveArticle(ctx, s, "slug", &model.Article{ID: "article", FavoritesCount: 5})
+	s.IncrFavoritesCount(ctx, "slug", 1)
+	s.IncrFavoritesCount(ctx, "slug", 1)
+	s.IncrFavoritesCount(ctx, "slug", -1)
+	if a := findArticle(ctx, s, "slug"); as.NotNil(a) {
+		as.Equal(6, a.FavoritesCount)
+	}
+
+	// The counter goes with the version, it is seeded again after an invalidation
+	s.InvalidateBySlug(ctx, "slug")
+	as.Nil(findArticle(ctx, s, "slug"))
+	saveArticle(ctx, s, "slug", &model.Article{ID: "article", FavoritesCount: 7})
+	if a := findArticle(ctx, s, "slug"); as.NotNil(a) {
+		as.Equal(7, a.FavoritesCount)
+	}
+}
+
+func TestArticleStoreFavoritedDuringLoad(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	_, client := newTestClient(t)
+	s := &ArticleStoreImpl{client}
+
+	// Missed and loaded with 5, then favorited before the save
+	_, v := s.FindOneBySlug(ctx, "slug")
+	s.IncrFavoritesCount(ctx, "slug", 1)
+	s.SaveBySlug(ctx, "slug", v, &model.Article{ID: "article", FavoritesCount: 5})
+	as.Nil(findArticle(ctx, s, "slug"), "A count loaded before the change should not be seeded")
+
+	saveArticle(ctx, s, "slug", &model.Article{ID: "article", FavoritesCount: 6})
+	if a := findArticle(ctx, s, "slug"); as.NotNil(a) {
+		as.Equal(6, a.FavoritesCount)
+	}
+
+	// Loaded before an update, saved after it
+	_, v = s.FindOneBySlug(ctx, "slug")
+	s.InvalidateBySlug(ctx, "slug")
+	s.SaveBySlug(ctx, "slug", v, &model.Article{ID: "article", Title: "Old"})
+	as.Nil(findArticle(ctx, s, "slug"), "An article loaded before an invalidation should not be saved")
+}
@@ -0,0 +1,142 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+type EventStore interface {
+	Publish(context.Context, string, *model.Event) error
+	Subscribe(...string) (<-chan *model.Event, func())
+	Resubscribe(<-chan *model.Event, ...string)
+}
+
+// Events are fanned out across instances through Redis pub/sub.
+// Every instance holds a single pattern subscription
+// and dispatches the messages to its local subscribers.
+// Without a client the events only reach the subscribers of this instance.
+type EventStoreImpl struct {
+	client  redis.UniversalClient
+	mu      sync.Mutex
+	subs    map[string]map[chan *model.Event]struct{}
+	members map[<-chan *model.Event]*eventMember
+	started bool
+}
+
+// A subscriber along with the channels it listens to
+type eventMember struct {
+	ch       chan *model.Event
+	channels []string
+}
+
+var eventPrefix = "events:"
+
+// Events are dropped for a subscriber that is this far behind
+const eventBuffer = 16
+
+func NewEventStore(c redis.UniversalClient) *EventStoreImpl {
+	return &EventStoreImpl{
+		client:  c,
+		subs:    make(map[string]map[chan *model.Event]struct{}),
+		members: make(map[<-chan *model.Event]*eventMember),
+	}
+}
+
+func NewLocalEventStore() *EventStoreImpl {
+	return NewEventStore(nil)
+}
+
+func (s *EventStoreImpl) Publish(ctx context.Context, channel string, e *model.Event) error {
+	if s.client == nil {
+		s.dispatch(channel, e)
+		return nil
+	}
+	return s.client.Publish(ctx, eventPrefix+channel, e).Err()
+}
+
+// Listen to the events of the given channels until the returned cancel func is called
+func (s *EventStoreImpl) Subscribe(channels ...string) (<-chan *model.Event, func()) {
+	ch := make(chan *model.Event, eventBuffer)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.started && s.client != nil {
+		s.started = true
+		go s.run()
+	}
+	m := &eventMember{ch: ch}
+	s.members[ch] = m
+	s.join(m, channels)
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.leave(m)
+			delete(s.members, ch)
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Replace the channels of a subscription, events already queued are kept
+func (s *EventStoreImpl) Resubscribe(ch <-chan *model.Event, channels ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.members[ch]
+	if !ok {
+		return
+	}
+	s.leave(m)
+	s.join(m, channels)
+}
+
+func (s *EventStoreImpl) join(m *eventMember, channels []string) {
+	for _, c := range channels {
+		if s.subs[c] == nil {
+			s.subs[c] = make(map[chan *model.Event]struct{})
+		}
+		s.subs[c][m.ch] = struct{}{}
+	}
+	m.channels = channels
+}
+
+func (s *EventStoreImpl) leave(m *eventMember) {
+	for _, c := range m.channels {
+		delete(s.subs[c], m.ch)
+		if len(s.subs[c]) == 0 {
+			delete(s.subs, c)
+		}
+	}
+	m.channels = nil
+}
+
+func (s *EventStoreImpl) run() {
+	ps := s.client.PSubscribe(context.Background(), eventPrefix+"*")
+	defer ps.Close()
+	for msg := range ps.Channel() {
+		e := new(model.Event)
+		if err := e.UnmarshalBinary([]byte(msg.Payload)); err != nil {
+			logrus.Warnf("Cannot decode event from %q, reason: %v", msg.Channel, err)
+			continue
+		}
+		s.dispatch(strings.TrimPrefix(msg.Channel, eventPrefix), e)
+	}
+}
+
+func (s *EventStoreImpl) dispatch(channel string, e *model.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs[channel] {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/go-redis/redis/v8"
+)
+
+type ListStoreImpl struct {
+	client redis.UniversalClient
+}
+
+// Article lists are cached per viewer, since what they can see differs.
+// Any article mutation drops every list, a user's own activity only theirs.
+type ListStore interface {
+	Find(context.Context, *model.FindArticlesArgs) model.Articles
+	Save(context.Context, *model.FindArticlesArgs, model.Articles)
+	Invalidate(context.Context)
+	InvalidateUser(context.Context, string)
+}
+
+var listPrefix = "lists"
+
+func listVersionKey() string {
+	return listPrefix + "|version"
+}
+
+func userListVersionKey(username string) string {
+	return fmt.Sprintf("%s|username:%s|version", listPrefix, username)
+}
+
+// Two args asking for the same page map to the same key,
+// fields that do not affect the query are left out.
+func ListKey(args *model.FindArticlesArgs) string {
+	q := url.Values{}
+	set := func(k, v string) {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	set("tag", args.Tag)
+	set("author", args.Author)
+	set("username", args.Username)
+	set("favorited", args.Favorited)
+	if args.Feed {
+		mode := args.FeedMode
+		if mode == "" {
+			mode = model.FeedModeAuthors
+		}
+		set("feed", mode)
+	}
+	if args.Bookmarked {
+		set("bookmarked", "true")
+		set("collection", args.Collection)
+	}
+	if args.Format == model.FormatHTML {
+		set("format", args.Format)
+	}
+	q.Set("limit", strconv.Itoa(args.Limit))
+	q.Set("offset", strconv.Itoa(args.Offset))
+	return q.Encode()
+}
+
+// The versions live on different cluster nodes, so they are pipelined rather than MGET
+func (s *ListStoreImpl) key(ctx context.Context, args *model.FindArticlesArgs) (string, error) {
+	pipe := s.client.Pipeline()
+	global := pipe.Get(ctx, listVersionKey())
+	user := pipe.Get(ctx, userListVersionKey(args.Username))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return "", err
+	}
+	v := [2]string{"0", "0"}
+	for i, cmd := range []*redis.StringCmd{global, user} {
+		if version, err := cmd.Result(); err == nil {
+			v[i] = version
+		}
+	}
+	return versionedListKey(v[0], v[1], args), nil
+}
+
+func versionedListKey(version, userVersion string, args *model.FindArticlesArgs) string {
+	return fmt.Sprintf("%s|v:%s|uv:%s|%s", listPrefix, version, userVersion, ListKey(args))
+}
+
+func (s *ListStoreImpl) Find(ctx context.Context, args *model.FindArticlesArgs) model.Articles {
+	key, err := s.key(ctx, args)
+	if err != nil {
+		return nil
+	}
+	res := model.Articles{}
+	if err := s.client.Get(ctx, key).Scan(&res); err != nil {
+		return nil
+	}
+	return res
+}
+
+func (s *ListStoreImpl) Save(ctx context.Context, args *model.FindArticlesArgs, articles model.Articles) {
+	key, err := s.key(ctx, args)
+	if err != nil {
+		return
+	}
+	s.client.SetEX(ctx, key, articles, config.CacheTTL)
+}
+
+// Like the article versions, these are never reused and expire with the lists
+func (s *ListStoreImpl) Invalidate(ctx context.Context) {
+	s.client.Set(ctx, listVersionKey(), newVersion(), config.CacheTTL)
+}
+
+func (s *ListStoreImpl) InvalidateUser(ctx context.Context, username string) {
+	s.client.Set(ctx, userListVersionKey(username), newVersion(), config.CacheTTL)
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListKey(t *testing.T) {
+	as := assert.New(t)
+
+	as.Equal(
+		ListKey(&model.FindArticlesArgs{Tag: "go", Limit: 10, FeedMode: model.FeedModeTags, Collection: "later", Format: model.FormatMarkdown}),
+		ListKey(&model.FindArticlesArgs{Tag: "go", Limit: 10}),
+		"Fields that do not apply should be ignored",
+	)
+	as.Equal(
+		ListKey(&model.FindArticlesArgs{Username: "jake", Feed: true, Limit: 10}),
+		ListKey(&model.FindArticlesArgs{Username: "jake", Feed: true, FeedMode: model.FeedModeAuthors, Limit: 10}),
+		"The default feed mode should be the same as the explicit one",
+	)
+	as.NotEqual(
+		ListKey(&model.FindArticlesArgs{Username: "jake", Limit: 10}),
+		ListKey(&model.FindArticlesArgs{Username: "jane", Limit: 10}),
+	)
+	as.NotEqual(
+		ListKey(&model.FindArticlesArgs{Limit: 10}),
+		ListKey(&model.FindArticlesArgs{Limit: 10, Offset: 10}),
+	)
+}
+
+func TestListStoreInvalidate(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	_, client := newTestClient(t)
+	s := &ListStoreImpl{client}
+
+	jake := &model.FindArticlesArgs{Username: "jake", Limit: 10}
+	feed := &model.FindArticlesArgs{Username: "jake", Feed: true, Limit: 10}
+	jane := &model.FindArticlesArgs{Username: "jane", Limit: 10}
+	save := func() {
+		for _, args := range []*model.FindArticlesArgs{jake, feed, jane} {
+			s.Save(ctx, args, model.Articles{{ID: args.Username}})
+		}
+	}
+
+	as.Nil(s.Find(ctx, jake))
+	save()
+	if res := s.Find(ctx, jake); as.Len(res, 1) {
+		as.Equal("jake", res[0].ID)
+	}
+	as.NotNil(s.Find(ctx, feed))
+
+	s.InvalidateUser(ctx, "jake")
+	as.Nil(s.Find(ctx, jake))
+	as.Nil(s.Find(ctx, feed))
+	as.NotNil(s.Find(ctx, jane), "Other users should keep their lists")
+
+	save()
+	s.Invalidate(ctx)
+	as.Nil(s.Find(ctx, jake))
+	as.Nil(s.Find(ctx, jane))
+}
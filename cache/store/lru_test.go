@@ -0,0 +1,47 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	as := assert.New(t)
+	c := newLRU(2)
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Get("a")
+	c.Set("c", 3, 0)
+
+	_, ok := c.Get("b")
+	as.False(ok, "The least recently used entry should be evicted")
+	if v, ok := c.Get("a"); as.True(ok) {
+		as.Equal(1, v)
+	}
+	as.Equal(2, c.Len())
+}
+
+func TestLRUExpires(t *testing.T) {
+	as := assert.New(t)
+	now := time.Now()
+	c := newLRU(10)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1, time.Minute)
+	c.Set("forever", 1, 0)
+	as.False(c.Add("a", 2, time.Minute), "A live entry should not be replaced")
+
+	now = now.Add(time.Minute)
+	_, ok := c.Get("a")
+	as.False(ok)
+	_, ok = c.Get("forever")
+	as.True(ok)
+	as.True(c.Add("a", 2, time.Minute), "An expired entry should be replaced")
+
+	c.Delete("a")
+	_, ok = c.Get("a")
+	as.False(ok)
+}
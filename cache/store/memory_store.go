@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+)
+
+// Keeps everything in the process, for a single instance without redis.
+// The stores share one LRU, so the size bounds all of them together.
+// Rate limits get their own, cached pages must not evict counters.
+func NewMemoryStore(size int) *CacheStore {
+	c := newLRU(size)
+	return &CacheStore{
+		&ArticleMemoryStore{cache: c},
+		&ViewerMemoryStore{c},
+		&ListMemoryStore{c},
+		NewLocalEventStore(),
+		NewRateMemoryStore(size),
+	}
+}
+
+// Articles are kept encoded, so callers can never change the cached copy
+type ArticleMemoryStore struct {
+	cache *lru
+
+	mu sync.Mutex
+	// Stands in for the version of every slug, it moves on with any change
+	gen uint64
+}
+
+func (s *ArticleMemoryStore) version() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strconv.FormatUint(s.gen, 10)
+}
+
+func (s *ArticleMemoryStore) FindOneBySlug(ctx context.Context, slug string) (*model.Article, string) {
+	v := s.version()
+	data, ok := s.cache.Get(articleKey(slug, ""))
+	if !ok {
+		return nil, v
+	}
+	count, ok := s.cache.Get(favoritesCountKey(slug, ""))
+	if !ok {
+		return nil, v
+	}
+	res := new(model.Article)
+	if err := res.UnmarshalBinary(data.([]byte)); err != nil {
+		return nil, v
+	}
+	res.FavoritesCount = int(atomic.LoadInt64(count.(*int64)))
+	return res, v
+}
+
+func (s *ArticleMemoryStore) SaveBySlug(ctx context.Context, slug, version string, a *model.Article) {
+	data, err := sharedArticle(a).MarshalBinary()
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strconv.FormatUint(s.gen, 10) != version {
+		return
+	}
+	s.cache.Set(articleKey(slug, ""), data, config.CacheTTL)
+	count := int64(a.FavoritesCount)
+	s.cache.Add(favoritesCountKey(slug, ""), &count, config.CacheTTL)
+}
+
+func (s *ArticleMemoryStore) InvalidateBySlug(ctx context.Context, slug string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gen++
+	s.cache.Delete(articleKey(slug, ""))
+	s.cache.Delete(favoritesCountKey(slug, ""))
+}
+
+func (s *ArticleMemoryStore) IncrFavoritesCount(ctx context.Context, slug string, n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if count, ok := s.cache.Get(favoritesCountKey(slug, "")); ok {
+		atomic.AddInt64(count.(*int64), n)
+		return
+	}
+	// A load in flight may predate the change, it must not seed the count
+	s.gen++
+}
+
+type ViewerMemoryStore struct {
+	cache *lru
+}
+
+type memoryViewer struct {
+	mu   sync.RWMutex
+	sets map[string]map[string]bool
+}
+
+func (s *ViewerMemoryStore) get(username string) *memoryViewer {
+	v, ok := s.cache.Get(viewerKey(username, viewerLoaded))
+	if !ok {
+		return nil
+	}
+	return v.(*memoryViewer)
+}
+
+func (s *ViewerMemoryStore) Overlay(ctx context.Context, username string, a *model.Article) bool {
+	if username == "" {
+		return overlay(username, a, &viewerFlags{})
+	}
+	v := s.get(username)
+	if v == nil {
+		return false
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	f := &viewerFlags{
+		favorited:  v.sets[viewerFavorites][a.ID],
+		bookmarked: v.sets[viewerBookmarks][a.ID],
+		following:  v.sets[viewerFollowing][articleAuthor(a)],
+		reactions:  []string{},
+	}
+	for _, r := range config.Reactions {
+		if v.sets[viewerReactions][reactionMember(a.ID, r)] {
+			f.reactions = append(f.reactions, r)
+		}
+	}
+	return overlay(username, a, f)
+}
+
+func (s *ViewerMemoryStore) Exists(ctx context.Context, username string) bool {
+	return s.get(username) != nil
+}
+
+func (s *ViewerMemoryStore) Save(ctx context.Context, username string, state *ViewerState) {
+	v := &memoryViewer{sets: map[string]map[string]bool{
+		viewerFavorites: {},
+		viewerBookmarks: {},
+		viewerFollowing: {},
+		viewerReactions: {},
+	}}
+	for _, id := range state.Favorites {
+		v.sets[viewerFavorites][id] = true
+	}
+	for _, id := range state.Bookmarks {
+		v.sets[viewerBookmarks][id] = true
+	}
+	for _, u := range state.Following {
+		v.sets[viewerFollowing][u] = true
+	}
+	for _, r := range state.Reactions {
+		if r.ArticleID != nil {
+			v.sets[viewerReactions][reactionMember(*r.ArticleID, r.Reaction)] = true
+		}
+	}
+	s.cache.Set(viewerKey(username, viewerLoaded), v, config.CacheTTL)
+}
+
+func (s *ViewerMemoryStore) update(username, set, member string, add bool) {
+	v := s.get(username)
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if add {
+		v.sets[set][member] = true
+	} else {
+		delete(v.sets[set], member)
+	}
+}
+
+func (s *ViewerMemoryStore) SetFavorited(ctx context.Context, username, articleID string, favorited bool) {
+	s.update(username, viewerFavorites, articleID, favorited)
+}
+
+func (s *ViewerMemoryStore) SetBookmarked(ctx context.Context, username, articleID string, bookmarked bool) {
+	s.update(username, viewerBookmarks, articleID, bookmarked)
+}
+
+func (s *ViewerMemoryStore) SetReacted(ctx context.Context, username, articleID, reaction string, reacted bool) {
+	s.update(username, viewerReactions, reactionMember(articleID, reaction), reacted)
+}
+
+func (s *ViewerMemoryStore) SetFollowing(ctx context.Context, username, following string, follows bool) {
+	s.update(username, viewerFollowing, following, follows)
+}
+
+// Versioned the same way as in redis. Reading a list touches the versions,
+// so they are always evicted after the lists written under an older one.
+type ListMemoryStore struct {
+	cache *lru
+}
+
+func (s *ListMemoryStore) key(args *model.FindArticlesArgs) string {
+	v := [2]string{"0", "0"}
+	for i, key := range []string{listVersionKey(), userListVersionKey(args.Username)} {
+		if version, ok := s.cache.Get(key); ok {
+			v[i] = version.(string)
+		}
+	}
+	return versionedListKey(v[0], v[1], args)
+}
+
+func (s *ListMemoryStore) Find(ctx context.Context, args *model.FindArticlesArgs) model.Articles {
+	data, ok := s.cache.Get(s.key(args))
+	if !ok {
+		return nil
+	}
+	res := model.Articles{}
+	if err := res.UnmarshalBinary(data.([]byte)); err != nil {
+		return nil
+	}
+	return res
+}
+
+func (s *ListMemoryStore) Save(ctx context.Context, args *model.FindArticlesArgs, articles model.Articles) {
+	data, err := articles.MarshalBinary()
+	if err != nil {
+		return
+	}
+	s.cache.Set(s.key(args), data, config.CacheTTL)
+}
+
+func (s *ListMemoryStore) Invalidate(ctx context.Context) {
+	s.cache.Set(listVersionKey(), newVersion(), config.CacheTTL)
+}
+
+func (s *ListMemoryStore) InvalidateUser(ctx context.Context, username string) {
+	s.cache.Set(userListVersionKey(username), newVersion(), config.CacheTTL)
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryArticleStore(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	s := NewMemoryStore(100).ArticleStore
+
+	s.IncrFavoritesCount(ctx, "slug", 1)
+	saveArticle(ctx, s, "slug", &model.Article{
+		ID:             "article",
+		Favorited:      true,
+		FavoritesCount: 2,
+		Author:         &model.ProfileRs{Username: "author", Following: true},
+	})
+	s.IncrFavoritesCount(ctx, "slug", 1)
+
+	a := findArticle(ctx, s, "slug")
+	if as.NotNil(a) {
+		as.Equal(3, a.FavoritesCount)
+		as.False(a.Favorited, "Viewer state should not be shared")
+		as.False(a.Author.Following, "Viewer state should not be shared")
+		a.Title = "Changed"
+	}
+	if a := findArticle(ctx, s, "slug"); as.NotNil(a) {
+		as.Empty(a.Title, "The cached copy should not be shared with callers")
+	}
+
+	s.InvalidateBySlug(ctx, "slug")
+	as.Nil(findArticle(ctx, s, "slug"))
+
+	_, v := s.FindOneBySlug(ctx, "slug")
+	s.IncrFavoritesCount(ctx, "slug", 1)
+	s.SaveBySlug(ctx, "slug", v, &model.Article{ID: "article", FavoritesCount: 3})
+	as.Nil(findArticle(ctx, s, "slug"), "A count loaded before the change should not be seeded")
+}
+
+func TestMemoryViewerStore(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	s := NewMemoryStore(100).ViewerStore
+
+	reactions := config.Reactions
+	config.Reactions = []string{"like", "love"}
+	defer func() { config.Reactions = reactions }()
+
+	article := func() *model.Article {
+		return &model.Article{ID: "article", Author: &model.ProfileRs{Username: "author", Private: true}}
+	}
+	as.False(s.Overlay(ctx, "jake", article()), "State should be loaded first")
+
+	s.Save(ctx, "jake", &ViewerState{Favorites: []string{"article"}})
+	as.True(s.Exists(ctx, "jake"))
+	as.False(s.Overlay(ctx, "jake", article()), "Private articles are hidden from non followers")
+
+	s.SetFollowing(ctx, "jake", "author", true)
+	s.SetReacted(ctx, "jake", "article", "love", true)
+	s.SetFavorited(ctx, "jake", "article", false)
+	a := article()
+	if as.True(s.Overlay(ctx, "jake", a)) {
+		as.False(a.Favorited)
+		as.True(a.Author.Following)
+		as.Equal([]string{"love"}, a.ViewerReactions)
+	}
+}
+
+func TestMemoryListStore(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	s := NewMemoryStore(100).ListStore
+
+	jake := &model.FindArticlesArgs{Username: "jake", Limit: 10}
+	jane := &model.FindArticlesArgs{Username: "jane", Limit: 10}
+	s.Save(ctx, jake, model.Articles{{ID: "article"}})
+	s.Save(ctx, jane, model.Articles{{ID: "article"}})
+	if res := s.Find(ctx, jake); as.Len(res, 1) {
+		as.Equal("article", res[0].ID)
+	}
+
+	s.InvalidateUser(ctx, "jake")
+	as.Nil(s.Find(ctx, jake))
+	as.NotNil(s.Find(ctx, jane))
+
+	s.Invalidate(ctx)
+	as.Nil(s.Find(ctx, jane))
+}
+
+func TestLocalEventStore(t *testing.T) {
+	as := assert.New(t)
+	s := NewLocalEventStore()
+
+	ch, cancel := s.Subscribe("user:jake")
+	defer cancel()
+	as.Nil(s.Publish(context.Background(), "user:jake", &model.Event{Type: "ping"}))
+
+	select {
+	case e := <-ch:
+		as.Equal("ping", e.Type)
+	case <-time.After(time.Second):
+		t.Fatal("Event should be delivered locally")
+	}
+
+	s.Resubscribe(ch, "user:jake", "tag:golang")
+	as.Nil(s.Publish(context.Background(), "tag:golang", &model.Event{Type: "article"}))
+	if e := <-ch; as.NotNil(e) {
+		as.Equal("article", e.Type, "Events of a channel added later should be delivered")
+	}
+	s.Resubscribe(ch, "user:jake")
+	as.Nil(s.Publish(context.Background(), "tag:golang", &model.Event{Type: "dropped"}))
+	as.Empty(ch, "Events of a channel left should not be delivered")
+}
@@ -11,11 +11,19 @@ type ArticleStoreMock struct {
 	mock.Mock
 }
 
-func (m *ArticleStoreMock) FindOneBySlug(ctx context.Context, arg1 string, arg2 string) *model.Article {
-	args := m.Called(ctx, arg1, arg2)
-	return args.Get(0).(*model.Article)
+func (m *ArticleStoreMock) FindOneBySlug(ctx context.Context, arg1 string) (*model.Article, string) {
+	args := m.Called(ctx, arg1)
+	return args.Get(0).(*model.Article), args.String(1)
 }
 
-func (m *ArticleStoreMock) SaveBySlug(ctx context.Context, arg1 string, arg2 string, arg3 *model.Article) {
+func (m *ArticleStoreMock) SaveBySlug(ctx context.Context, arg1, arg2 string, arg3 *model.Article) {
 	m.Called(ctx, arg1, arg2, arg3)
 }
+
+func (m *ArticleStoreMock) InvalidateBySlug(ctx context.Context, arg1 string) {
+	m.Called(ctx, arg1)
+}
+
+func (m *ArticleStoreMock) IncrFavoritesCount(ctx context.Context, arg1 string, arg2 int64) {
+	m.Called(ctx, arg1, arg2)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventStoreMock struct {
+	mock.Mock
+}
+
+func (m *EventStoreMock) Publish(ctx context.Context, channel string, e *model.Event) error {
+	args := m.Called(ctx, channel, e)
+	return args.Error(0)
+}
+
+func (m *EventStoreMock) Subscribe(channels ...string) (<-chan *model.Event, func()) {
+	args := m.Called(channels)
+	return args.Get(0).(<-chan *model.Event), args.Get(1).(func())
+}
+
+func (m *EventStoreMock) Resubscribe(ch <-chan *model.Event, channels ...string) {
+	m.Called(ch, channels)
+}
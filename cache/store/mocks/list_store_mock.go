@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type ListStoreMock struct {
+	mock.Mock
+}
+
+func (m *ListStoreMock) Find(ctx context.Context, arg1 *model.FindArticlesArgs) model.Articles {
+	args := m.Called(ctx, arg1)
+	return args.Get(0).(model.Articles)
+}
+
+func (m *ListStoreMock) Save(ctx context.Context, arg1 *model.FindArticlesArgs, arg2 model.Articles) {
+	m.Called(ctx, arg1, arg2)
+}
+
+func (m *ListStoreMock) Invalidate(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *ListStoreMock) InvalidateUser(ctx context.Context, arg1 string) {
+	m.Called(ctx, arg1)
+}
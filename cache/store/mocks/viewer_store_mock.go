@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/cache/store"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type ViewerStoreMock struct {
+	mock.Mock
+}
+
+func (m *ViewerStoreMock) Overlay(ctx context.Context, arg1 string, arg2 *model.Article) bool {
+	args := m.Called(ctx, arg1, arg2)
+	return args.Bool(0)
+}
+
+func (m *ViewerStoreMock) Exists(ctx context.Context, arg1 string) bool {
+	args := m.Called(ctx, arg1)
+	return args.Bool(0)
+}
+
+func (m *ViewerStoreMock) Save(ctx context.Context, arg1 string, arg2 *store.ViewerState) {
+	m.Called(ctx, arg1, arg2)
+}
+
+func (m *ViewerStoreMock) SetFavorited(ctx context.Context, arg1 string, arg2 string, arg3 bool) {
+	m.Called(ctx, arg1, arg2, arg3)
+}
+
+func (m *ViewerStoreMock) SetBookmarked(ctx context.Context, arg1 string, arg2 string, arg3 bool) {
+	m.Called(ctx, arg1, arg2, arg3)
+}
+
+func (m *ViewerStoreMock) SetReacted(ctx context.Context, arg1 string, arg2 string, arg3 string, arg4 bool) {
+	m.Called(ctx, arg1, arg2, arg3, arg4)
+}
+
+func (m *ViewerStoreMock) SetFollowing(ctx context.Context, arg1 string, arg2 string, arg3 bool) {
+	m.Called(ctx, arg1, arg2, arg3)
+}
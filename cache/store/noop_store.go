@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/config"
+
+	"github.com/ashalfarhan/realworld/model"
+)
+
+// Caches nothing, every read goes to the database.
+// Events still reach the subscribers of this instance, and rate limits still hold in it.
+func NewNoopStore() *CacheStore {
+	return &CacheStore{
+		noopArticleStore{},
+		noopViewerStore{},
+		noopListStore{},
+		NewLocalEventStore(),
+		NewRateMemoryStore(config.CacheMaxEntries),
+	}
+}
+
+type noopArticleStore struct{}
+
+func (noopArticleStore) FindOneBySlug(context.Context, string) (*model.Article, string) {
+	return nil, ""
+}
+func (noopArticleStore) SaveBySlug(context.Context, string, string, *model.Article) {}
+func (noopArticleStore) InvalidateBySlug(context.Context, string)                   {}
+func (noopArticleStore) IncrFavoritesCount(context.Context, string, int64)          {}
+
+type noopViewerStore struct{}
+
+func (noopViewerStore) Overlay(context.Context, string, *model.Article) bool { return false }
+
+// Nothing would be kept, so nothing should be loaded
+func (noopViewerStore) Exists(context.Context, string) bool                      { return true }
+func (noopViewerStore) Save(context.Context, string, *ViewerState)               {}
+func (noopViewerStore) SetFavorited(context.Context, string, string, bool)       {}
+func (noopViewerStore) SetBookmarked(context.Context, string, string, bool)      {}
+func (noopViewerStore) SetReacted(context.Context, string, string, string, bool) {}
+func (noopViewerStore) SetFollowing(context.Context, string, string, bool)       {}
+
+type noopListStore struct{}
+
+func (noopListStore) Find(context.Context, *model.FindArticlesArgs) model.Articles  { return nil }
+func (noopListStore) Save(context.Context, *model.FindArticlesArgs, model.Articles) {}
+func (noopListStore) Invalidate(context.Context)                                    {}
+func (noopListStore) InvalidateUser(context.Context, string)                        {}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+// The outcome of one request against a limit
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	// Until the current window ends and frees up capacity
+	Reset   time.Duration
+	Allowed bool
+}
+
+// Sliding window counters: the count of the previous window is weighted
+// by how much of it still overlaps the window ending now.
+type RateStore interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (*RateLimit, error)
+}
+
+const ratePrefix = "ratelimit"
+
+// Both windows of a key share a hash tag, the script reads them together
+func rateKey(key string, window int64) string {
+	return fmt.Sprintf("%s|{%s}|w:%d", ratePrefix, key, window)
+}
+
+// Which window now falls in, and how much of the previous one still counts
+func rateWindow(now time.Time, window time.Duration) (int64, float64, time.Duration) {
+	n := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() - n*int64(window))
+	return n, 1 - float64(elapsed)/float64(window), window - elapsed
+}
+
+func newRateLimit(limit, count int, reset time.Duration) *RateLimit {
+	res := &RateLimit{Limit: limit, Reset: reset, Allowed: count < limit}
+	if res.Allowed {
+		count++
+	}
+	if res.Remaining = limit - count; res.Remaining < 0 {
+		res.Remaining = 0
+	}
+	return res
+}
+
+type RateStoreImpl struct {
+	client redis.UniversalClient
+	now    func() time.Time
+}
+
+func NewRateStore(c redis.UniversalClient) *RateStoreImpl {
+	return &RateStoreImpl{c, time.Now}
+}
+
+// Counts the request only when it is allowed, so rejected clients do not push their window further
+var slidingWindow = redis.NewScript(`
+local count = math.floor(tonumber(redis.call("GET", KEYS[2]) or "0") * tonumber(ARGV[2]))
+	+ tonumber(redis.call("GET", KEYS[1]) or "0")
+if count < tonumber(ARGV[1]) then
+	redis.call("INCR", KEYS[1])
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+end
+return count
+`)
+
+func (s *RateStoreImpl) Allow(ctx context.Context, key string, limit int, window time.Duration) (*RateLimit, error) {
+	n, weight, reset := rateWindow(s.now(), window)
+	keys := []string{rateKey(key, n), rateKey(key, n-1)}
+	// The current window is still read as the previous one during the next
+	ttl := (2 * window).Milliseconds()
+	count, err := slidingWindow.Run(ctx, s.client, keys, limit, strconv.FormatFloat(weight, 'f', -1, 64), ttl).Int()
+	if err != nil {
+		return nil, err
+	}
+	return newRateLimit(limit, count, reset), nil
+}
+
+// Counts in process, every instance then enforces the limit on its own
+type RateMemoryStore struct {
+	mu    sync.Mutex
+	cache *lru
+}
+
+func NewRateMemoryStore(size int) *RateMemoryStore {
+	return &RateMemoryStore{cache: newLRU(size)}
+}
+
+func (s *RateMemoryStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (*RateLimit, error) {
+	n, weight, reset := rateWindow(s.cache.now(), window)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	curr, _ := s.cache.Get(rateKey(key, n))
+	prev, _ := s.cache.Get(rateKey(key, n-1))
+	c, _ := curr.(int)
+	p, _ := prev.(int)
+	count := int(float64(p)*weight) + c
+	if count < limit {
+		s.cache.Set(rateKey(key, n), c+1, 2*window)
+	}
+	return newRateLimit(limit, count, reset), nil
+}
+
+// Counts in redis, and in process while redis cannot be reached
+type fallbackRateStore struct {
+	primary  RateStore
+	fallback RateStore
+}
+
+func NewFallbackRateStore(primary, fallback RateStore) RateStore {
+	return &fallbackRateStore{primary, fallback}
+}
+
+func (s *fallbackRateStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (*RateLimit, error) {
+	res, err := s.primary.Allow(ctx, key, limit, window)
+	if err == nil {
+		return res, nil
+	}
+	logrus.Warnf("Cannot rate limit %q in redis, counting in process, reason: %v", key, err)
+	return s.fallback.Allow(ctx, key, limit, window)
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRateStore(t *testing.T, s RateStore, advance func(time.Duration)) {
+	as := assert.New(t)
+	ctx := context.Background()
+	allow := func(key string) *RateLimit {
+		res, err := s.Allow(ctx, key, 3, time.Minute)
+		as.NoError(err)
+		return res
+	}
+
+	for i := 2; i >= 0; i-- {
+		res := allow("jake")
+		as.True(res.Allowed)
+		as.Equal(i, res.Remaining)
+	}
+	res := allow("jake")
+	as.False(res.Allowed)
+	as.Equal(0, res.Remaining)
+	as.Equal(time.Minute, res.Reset)
+	as.True(allow("jane").Allowed, "Keys should be limited on their own")
+
+	// Half of the previous window still counts, rounded down to one request
+	advance(90 * time.Second)
+	as.True(allow("jake").Allowed)
+	res = allow("jake")
+	as.True(res.Allowed)
+	as.Equal(0, res.Remaining)
+	as.Equal(30*time.Second, res.Reset)
+	as.False(allow("jake").Allowed)
+
+	advance(time.Minute)
+	// Two of the three requests of the previous window were allowed, half of them still count
+	as.Equal(1, allow("jake").Remaining, "Rejected requests should not be counted")
+}
+
+func TestRateStore(t *testing.T) {
+	_, client := newTestClient(t)
+	now := time.Unix(0, 0).Add(1000 * time.Minute)
+	s := NewRateStore(client)
+	s.now = func() time.Time { return now }
+	testRateStore(t, s, func(d time.Duration) { now = now.Add(d) })
+}
+
+func TestRateMemoryStore(t *testing.T) {
+	now := time.Unix(0, 0).Add(1000 * time.Minute)
+	s := NewRateMemoryStore(10)
+	s.cache.now = func() time.Time { return now }
+	testRateStore(t, s, func(d time.Duration) { now = now.Add(d) })
+}
+
+type failingRateStore struct{}
+
+func (failingRateStore) Allow(context.Context, string, int, time.Duration) (*RateLimit, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestFallbackRateStore(t *testing.T) {
+	as := assert.New(t)
+	s := NewFallbackRateStore(failingRateStore{}, NewRateMemoryStore(10))
+
+	res, err := s.Allow(context.Background(), "jake", 1, time.Minute)
+	as.NoError(err)
+	as.True(res.Allowed)
+	res, err = s.Allow(context.Background(), "jake", 1, time.Minute)
+	as.NoError(err)
+	as.False(res.Allowed, "The limit should still hold while redis is down")
+}
@@ -1,13 +1,24 @@
 package store
 
-import "github.com/go-redis/redis/v8"
+import (
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/go-redis/redis/v8"
+)
 
 type CacheStore struct {
 	ArticleStore ArticleStore
+	ViewerStore  ViewerStore
+	ListStore    ListStore
+	EventStore   EventStore
+	RateStore    RateStore
 }
 
-func NewCacheStore(c *redis.Client) *CacheStore {
+func NewCacheStore(c redis.UniversalClient) *CacheStore {
 	return &CacheStore{
 		&ArticleStoreImpl{c},
+		&ViewerStoreImpl{c},
+		&ListStoreImpl{c},
+		NewEventStore(c),
+		NewFallbackRateStore(NewRateStore(c), NewRateMemoryStore(config.CacheMaxEntries)),
 	}
 }
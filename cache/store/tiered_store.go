@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+// Hits and misses of the article cache per tier, served under "/debug/vars"
+var articleStats = expvar.NewMap("cache_articles")
+
+// Keeps hot articles in process in front of the shared ArticleStore.
+// Every change is broadcast, so each instance drops its local copy.
+type TieredArticleStore struct {
+	local  *lru
+	ttl    time.Duration
+	shared ArticleStore
+	client redis.UniversalClient
+
+	mu sync.Mutex
+	// Counts the invalidations seen here, a read from the shared tier
+	// that overlaps one may have returned the copy being dropped.
+	gen uint64
+}
+
+var invalidateChannel = prefix + ":invalidate"
+
+func NewTieredArticleStore(c redis.UniversalClient, shared ArticleStore, size int, ttl time.Duration) *TieredArticleStore {
+	s := &TieredArticleStore{local: newLRU(size), ttl: ttl, shared: shared, client: c}
+	ctx := context.Background()
+	ps := c.Subscribe(ctx, invalidateChannel)
+	// Wait for the confirmation, an invalidation sent before would be missed
+	if _, err := ps.Receive(ctx); err != nil {
+		logrus.Warnf("Cannot subscribe to %q, reason: %v", invalidateChannel, err)
+	}
+	go s.listen(ps)
+	return s
+}
+
+func (s *TieredArticleStore) listen(ps *redis.PubSub) {
+	defer ps.Close()
+	for msg := range ps.Channel() {
+		s.drop(msg.Payload)
+	}
+}
+
+// A local copy along with the shared version it was read under
+type localArticle struct {
+	data    []byte
+	version string
+}
+
+func (s *TieredArticleStore) FindOneBySlug(ctx context.Context, slug string) (*model.Article, string) {
+	if v, ok := s.local.Get(slug); ok {
+		l := v.(*localArticle)
+		res := new(model.Article)
+		if err := res.UnmarshalBinary(l.data); err == nil {
+			articleStats.Add("local_hits", 1)
+			return res, l.version
+		}
+	}
+	articleStats.Add("local_misses", 1)
+
+	gen := s.generation()
+	a, version := s.shared.FindOneBySlug(ctx, slug)
+	if a == nil {
+		articleStats.Add("shared_misses", 1)
+		return nil, version
+	}
+	articleStats.Add("shared_hits", 1)
+	s.keep(slug, a, version, gen)
+	return a, version
+}
+
+// The shared tier holds the counts, so the local copy is only taken from there
+func (s *TieredArticleStore) SaveBySlug(ctx context.Context, slug, version string, a *model.Article) {
+	s.shared.SaveBySlug(ctx, slug, version, a)
+}
+
+func (s *TieredArticleStore) InvalidateBySlug(ctx context.Context, slug string) {
+	s.shared.InvalidateBySlug(ctx, slug)
+	s.broadcast(ctx, slug)
+}
+
+func (s *TieredArticleStore) IncrFavoritesCount(ctx context.Context, slug string, n int64) {
+	s.shared.IncrFavoritesCount(ctx, slug, n)
+	s.broadcast(ctx, slug)
+}
+
+func (s *TieredArticleStore) generation() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gen
+}
+
+// Only kept when nothing was invalidated since the read began at gen
+func (s *TieredArticleStore) keep(slug string, a *model.Article, version string, gen uint64) {
+	data, err := a.MarshalBinary()
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gen == gen {
+		s.local.Set(slug, &localArticle{data, version}, s.ttl)
+	}
+}
+
+func (s *TieredArticleStore) drop(slug string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gen++
+	s.local.Delete(slug)
+}
+
+// Dropped here right away, the others follow once they get the message
+func (s *TieredArticleStore) broadcast(ctx context.Context, slug string) {
+	s.drop(slug)
+	if err := s.client.Publish(ctx, invalidateChannel, slug).Err(); err != nil {
+		logrus.Warnf("Cannot broadcast invalidation of %q, reason: %v", slug, err)
+	}
+}
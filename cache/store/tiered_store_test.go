@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func statValue(name string) int64 {
+	if v, ok := articleStats.Get(name).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+func TestTieredArticleStore(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	srv, client := newTestClient(t)
+	other := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer other.Close()
+
+	// Two instances sharing the same redis
+	a := NewTieredArticleStore(client, &ArticleStoreImpl{client}, 10, time.Minute)
+	b := NewTieredArticleStore(other, &ArticleStoreImpl{other}, 10, time.Minute)
+
+	as.Nil(findArticle(ctx, b, "slug"))
+	saveArticle(ctx, a, "slug", &model.Article{ID: "article", FavoritesCount: 1})
+
+	localHits, sharedHits := statValue("local_hits"), statValue("shared_hits")
+	as.NotNil(findArticle(ctx, b, "slug"))
+	as.Equal(sharedHits+1, statValue("shared_hits"), "The first read should come from redis")
+	if res := findArticle(ctx, b, "slug"); as.NotNil(res) {
+		as.Equal(1, res.FavoritesCount)
+		res.FavoritesCount = 5
+	}
+	as.Equal(localHits+1, statValue("local_hits"), "The next one should be kept in process")
+	if res := findArticle(ctx, b, "slug"); as.NotNil(res) {
+		as.Equal(1, res.FavoritesCount, "The local copy should not be shared with callers")
+	}
+
+	a.IncrFavoritesCount(ctx, "slug", 1)
+	as.Eventually(func() bool {
+		res := findArticle(ctx, b, "slug")
+		return res != nil && res.FavoritesCount == 2
+	}, time.Second, 10*time.Millisecond, "Other instances should drop their copy on a change")
+
+	a.InvalidateBySlug(ctx, "slug")
+	as.Eventually(func() bool {
+		return findArticle(ctx, b, "slug") == nil
+	}, time.Second, 10*time.Millisecond, "Other instances should drop their copy on invalidation")
+}
+
+// Runs after the shared read returns, as if a change landed meanwhile
+type racedArticleStore struct {
+	ArticleStore
+	during func()
+}
+
+func (s *racedArticleStore) FindOneBySlug(ctx context.Context, slug string) (*model.Article, string) {
+	a, v := s.ArticleStore.FindOneBySlug(ctx, slug)
+	if a != nil && s.during != nil {
+		s.during()
+		s.during = nil
+	}
+	return a, v
+}
+
+func TestTieredArticleStoreInvalidatedDuringRead(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	_, client := newTestClient(t)
+
+	shared := &racedArticleStore{ArticleStore: &ArticleStoreImpl{client}}
+	s := NewTieredArticleStore(client, shared, 10, time.Minute)
+	saveArticle(ctx, s, "slug", &model.Article{ID: "article", FavoritesCount: 1})
+	shared.during = func() { s.IncrFavoritesCount(ctx, "slug", 1) }
+
+	if res := findArticle(ctx, s, "slug"); as.NotNil(res) {
+		as.Equal(1, res.FavoritesCount, "The read began before the change")
+	}
+	if res := findArticle(ctx, s, "slug"); as.NotNil(res) {
+		as.Equal(2, res.FavoritesCount, "What was read during a change should not be kept")
+	}
+}
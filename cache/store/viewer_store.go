@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/go-redis/redis/v8"
+)
+
+// What a single user has done to articles and authors,
+// overlaid on the shared cached articles.
+type ViewerState struct {
+	Favorites []string
+	Bookmarks []string
+	Following []string
+	Reactions []*model.Reaction
+}
+
+type ViewerStoreImpl struct {
+	client redis.UniversalClient
+}
+
+type ViewerStore interface {
+	Overlay(context.Context, string, *model.Article) bool
+	Exists(context.Context, string) bool
+	Save(context.Context, string, *ViewerState)
+	SetFavorited(context.Context, string, string, bool)
+	SetBookmarked(context.Context, string, string, bool)
+	SetReacted(context.Context, string, string, string, bool)
+	SetFollowing(context.Context, string, string, bool)
+}
+
+var viewerPrefix = "viewers"
+
+const (
+	viewerLoaded    = "loaded"
+	viewerFavorites = "favorites"
+	viewerBookmarks = "bookmarks"
+	viewerFollowing = "following"
+	viewerReactions = "reactions"
+)
+
+// Empty sets do not exist in redis, the marker tells a loaded
+// but empty state apart from one that was never loaded.
+// The scripts touch the marker and a set at once, so they share a hash tag.
+func viewerKey(username, set string) string {
+	return fmt.Sprintf("%s|{username:%s}|%s", viewerPrefix, username, set)
+}
+
+func reactionMember(articleID, reaction string) string {
+	return articleID + ":" + reaction
+}
+
+// Keeps a loaded state in sync, a missing one is loaded fresh on the next read.
+// The set may have been created by this very call, so it takes the TTL of the marker.
+var updateIfExists = redis.NewScript(`
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl == -2 then
+	return false
+end
+local res = redis.call(ARGV[1], KEYS[2], ARGV[2])
+if ttl > 0 then
+	redis.call("PEXPIRE", KEYS[2], ttl)
+end
+return res`)
+
+// Set the viewer specific fields of "a" for "username",
+// reports false when they are not cached or the article is not visible to them.
+func (s *ViewerStoreImpl) Overlay(ctx context.Context, username string, a *model.Article) bool {
+	if username == "" {
+		return overlay(username, a, &viewerFlags{})
+	}
+
+	pipe := s.client.Pipeline()
+	loaded := pipe.Exists(ctx, viewerKey(username, viewerLoaded))
+	favorited := pipe.SIsMember(ctx, viewerKey(username, viewerFavorites), a.ID)
+	bookmarked := pipe.SIsMember(ctx, viewerKey(username, viewerBookmarks), a.ID)
+	following := pipe.SIsMember(ctx, viewerKey(username, viewerFollowing), articleAuthor(a))
+	reacted := make([]*redis.BoolCmd, len(config.Reactions))
+	for i, r := range config.Reactions {
+		reacted[i] = pipe.SIsMember(ctx, viewerKey(username, viewerReactions), reactionMember(a.ID, r))
+	}
+	if _, err := pipe.Exec(ctx); err != nil || loaded.Val() == 0 {
+		return false
+	}
+
+	f := &viewerFlags{favorited.Val(), bookmarked.Val(), following.Val(), []string{}}
+	for i, r := range config.Reactions {
+		if reacted[i].Val() {
+			f.reactions = append(f.reactions, r)
+		}
+	}
+	return overlay(username, a, f)
+}
+
+type viewerFlags struct {
+	favorited  bool
+	bookmarked bool
+	following  bool
+	reactions  []string
+}
+
+func articleAuthor(a *model.Article) string {
+	if a.Author != nil {
+		return a.Author.Username
+	}
+	return a.AuthorUsername
+}
+
+// Private articles are only visible to their author and the author's followers
+func overlay(username string, a *model.Article, f *viewerFlags) bool {
+	if a.Author != nil && a.Author.Private && username != articleAuthor(a) && !f.following {
+		return false
+	}
+	a.Favorited, a.Bookmarked = f.favorited, f.bookmarked
+	if a.Author != nil {
+		a.Author.Following = f.following
+	}
+	a.ViewerReactions = f.reactions
+	if a.ViewerReactions == nil {
+		a.ViewerReactions = []string{}
+	}
+	return true
+}
+
+func (s *ViewerStoreImpl) Exists(ctx context.Context, username string) bool {
+	n, err := s.client.Exists(ctx, viewerKey(username, viewerLoaded)).Result()
+	return err == nil && n > 0
+}
+
+func (s *ViewerStoreImpl) Save(ctx context.Context, username string, v *ViewerState) {
+	reactions := []string{}
+	for _, r := range v.Reactions {
+		if r.ArticleID != nil {
+			reactions = append(reactions, reactionMember(*r.ArticleID, r.Reaction))
+		}
+	}
+	sets := map[string][]string{
+		viewerFavorites: v.Favorites,
+		viewerBookmarks: v.Bookmarks,
+		viewerFollowing: v.Following,
+		viewerReactions: reactions,
+	}
+
+	pipe := s.client.TxPipeline()
+	for set, members := range sets {
+		key := viewerKey(username, set)
+		pipe.Del(ctx, key)
+		if len(members) == 0 {
+			continue
+		}
+		values := make([]interface{}, len(members))
+		for i, m := range members {
+			values[i] = m
+		}
+		pipe.SAdd(ctx, key, values...)
+		pipe.Expire(ctx, key, config.CacheTTL)
+	}
+	pipe.Set(ctx, viewerKey(username, viewerLoaded), 1, config.CacheTTL)
+	pipe.Exec(ctx)
+}
+
+func (s *ViewerStoreImpl) update(ctx context.Context, username, set, member string, add bool) {
+	cmd := "SREM"
+	if add {
+		cmd = "SADD"
+	}
+	keys := []string{viewerKey(username, viewerLoaded), viewerKey(username, set)}
+	updateIfExists.Run(ctx, s.client, keys, cmd, member)
+}
+
+func (s *ViewerStoreImpl) SetFavorited(ctx context.Context, username, articleID string, favorited bool) {
+	s.update(ctx, username, viewerFavorites, articleID, favorited)
+}
+
+func (s *ViewerStoreImpl) SetBookmarked(ctx context.Context, username, articleID string, bookmarked bool) {
+	s.update(ctx, username, viewerBookmarks, articleID, bookmarked)
+}
+
+func (s *ViewerStoreImpl) SetReacted(ctx context.Context, username, articleID, reaction string, reacted bool) {
+	s.update(ctx, username, viewerReactions, reactionMember(articleID, reaction), reacted)
+}
+
+func (s *ViewerStoreImpl) SetFollowing(ctx context.Context, username, following string, follows bool) {
+	s.update(ctx, username, viewerFollowing, following, follows)
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewerStoreOverlay(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	srv, client := newTestClient(t)
+	s := &ViewerStoreImpl{client}
+
+	reactions := config.Reactions
+	config.Reactions = []string{"like", "love"}
+	defer func() { config.Reactions = reactions }()
+
+	article := func() *model.Article {
+		return &model.Article{ID: "article", AuthorUsername: "author", Author: &model.ProfileRs{Username: "author"}}
+	}
+
+	as.False(s.Overlay(ctx, "jake", article()), "State should be loaded first")
+	as.False(s.Exists(ctx, "jake"))
+
+	articleID := "article"
+	s.Save(ctx, "jake", &ViewerState{
+		Favorites: []string{"article"},
+		Bookmarks: []string{},
+		Following: []string{"author"},
+		Reactions: []*model.Reaction{{ArticleID: &articleID, Reaction: "love"}},
+	})
+	as.True(s.Exists(ctx, "jake"))
+
+	a := article()
+	if as.True(s.Overlay(ctx, "jake", a)) {
+		as.True(a.Favorited)
+		as.False(a.Bookmarked)
+		as.True(a.Author.Following)
+		as.Equal([]string{"love"}, a.ViewerReactions)
+	}
+
+	s.SetFavorited(ctx, "jake", "article", false)
+	s.SetBookmarked(ctx, "jake", "article", true)
+	s.SetReacted(ctx, "jake", "article", "like", true)
+	s.SetFollowing(ctx, "jake", "author", false)
+	a = article()
+	if as.True(s.Overlay(ctx, "jake", a)) {
+		as.False(a.Favorited)
+		as.True(a.Bookmarked)
+		as.False(a.Author.Following)
+		as.Equal([]string{"like", "love"}, a.ViewerReactions)
+	}
+	as.Equal(time.Minute, srv.TTL(viewerKey("jake", viewerBookmarks)), "A set created by an update should expire with the state")
+
+	srv.FastForward(time.Minute)
+	s.SetFavorited(ctx, "jake", "article", true)
+	as.False(srv.Exists(viewerKey("jake", viewerFavorites)), "An expired state should not be updated")
+	as.False(s.Overlay(ctx, "jake", article()))
+}
+
+func TestViewerStoreOverlayPrivate(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.Background()
+	_, client := newTestClient(t)
+	s := &ViewerStoreImpl{client}
+
+	article := func() *model.Article {
+		return &model.Article{ID: "article", AuthorUsername: "author", Author: &model.ProfileRs{Username: "author", Private: true}}
+	}
+	s.Save(ctx, "jake", &ViewerState{})
+	s.Save(ctx, "author", &ViewerState{})
+
+	as.False(s.Overlay(ctx, "", article()), "Private articles are hidden from guests")
+	as.False(s.Overlay(ctx, "jake", article()), "Private articles are hidden from non followers")
+	as.True(s.Overlay(ctx, "author", article()), "Authors can see their own articles")
+
+	s.SetFollowing(ctx, "jake", "author", true)
+	as.True(s.Overlay(ctx, "jake", article()))
+
+	public := &model.Article{ID: "article", Favorited: true, Author: &model.ProfileRs{Username: "author"}}
+	if as.True(s.Overlay(ctx, "", public)) {
+		as.False(public.Favorited)
+		as.Empty(public.ViewerReactions)
+	}
+}
@@ -11,6 +11,7 @@ var (
 	ErrUnauthorized = errors.New("unauthorized error")
 	ErrForbidden    = errors.New("forbidden error")
 	ErrNotFound     = errors.New("resource not found")
+	ErrRateLimited  = errors.New("too many requests")
 	GeneralError    = BuildError(500, ErrInternal)
 )
 
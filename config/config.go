@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -15,7 +17,64 @@ var (
 	Port          string
 	Env           string
 	MigrationPath string
-	CacheTTL      time.Duration
+
+	// "redis://" or "rediss://" for TLS, with the DB index as the path
+	RedisURL string
+	// Several addresses make a cluster, or the sentinels along with RedisMasterName
+	RedisAddrs      []string
+	RedisMasterName string
+	// Connections per node, zero keeps the default of 10 per CPU
+	RedisPoolSize     int
+	RedisDialTimeout  time.Duration
+	RedisReadTimeout  time.Duration
+	RedisWriteTimeout time.Duration
+
+	// How long a cached article lives, mutations invalidate it before that
+	CacheTTL time.Duration
+	// Where the cache lives, "redis", "memory" for a single instance, or "none"
+	CacheDriver string
+	// Entries the memory driver keeps before evicting the least recently used
+	CacheMaxEntries int
+	// How long articles are kept in process in front of redis, zero disables it.
+	// Invalidations are broadcast, this bounds the staleness when one is missed.
+	CacheLocalTTL time.Duration
+
+	// Serve the expvar metrics under "/debug/vars"
+	DebugVars bool
+
+	// Requests allowed per window for each user, or client IP when anonymous.
+	// The general limit covers the whole API, login, registration and
+	// comments are also held to their own stricter ones. Zero disables a limit.
+	RateLimitWindow   time.Duration
+	RateLimitGeneral  int
+	RateLimitAuth     int
+	RateLimitComments int
+	// Take the client IP from the last X-Forwarded-For entry, only behind a proxy that sets it
+	TrustProxy bool
+
+	// How long after posting a comment can still be edited, zero means forever
+	CommentEditWindow time.Duration
+
+	// Allowed reactions on articles and comments
+	Reactions []string
+
+	// Where uploaded files are kept, "local" or "s3"
+	UploadDriver string
+	// Directory of the local driver, served under "/uploads"
+	UploadDir string
+	// Public URL the keys of uploaded files are appended to,
+	// defaults to where the driver serves the files from
+	UploadBaseURL string
+	// Max size in bytes and max width or height in pixels of an uploaded image
+	UploadMaxSize      int64
+	UploadMaxDimension int
+
+	// Any S3-compatible storage, addressed path-style as {endpoint}/{bucket}/{key}
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
 )
 
 func Load() {
@@ -27,8 +86,96 @@ func Load() {
 		Env = "dev"
 	}
 	MigrationPath = os.Getenv("MIGRATION_PATH")
-	CacheTTL = time.Microsecond * 1 // Change to microsecond if testing with postman spec
+	CacheTTL = durationEnv("CACHE_TTL", 10*time.Minute)
+	CacheDriver = stringEnv("CACHE_DRIVER", "redis")
+	CacheMaxEntries = intEnv("CACHE_MAX_ENTRIES", 10000)
+	CacheLocalTTL = durationEnv("CACHE_LOCAL_TTL", 0)
+	DebugVars = boolEnv("DEBUG_VARS", false)
 	Addr = fmt.Sprintf("%s:%s", os.Getenv("HOST"), Port)
 	PgSource = os.Getenv("POSTGRES_URL")
 	RedisPass = os.Getenv("REDIS_PASSWORD")
+	RedisURL = stringEnv("REDIS_URL", "redis://localhost:6379/0")
+	RedisAddrs = listEnv("REDIS_ADDRS", nil)
+	RedisMasterName = os.Getenv("REDIS_MASTER_NAME")
+	RedisPoolSize = intEnv("REDIS_POOL_SIZE", 0)
+	RedisDialTimeout = durationEnv("REDIS_DIAL_TIMEOUT", 5*time.Second)
+	RedisReadTimeout = durationEnv("REDIS_READ_TIMEOUT", 3*time.Second)
+	RedisWriteTimeout = durationEnv("REDIS_WRITE_TIMEOUT", 3*time.Second)
+	RateLimitWindow = durationEnv("RATE_LIMIT_WINDOW", time.Minute)
+	RateLimitGeneral = intEnv("RATE_LIMIT_GENERAL", 300)
+	RateLimitAuth = intEnv("RATE_LIMIT_AUTH", 10)
+	RateLimitComments = intEnv("RATE_LIMIT_COMMENTS", 20)
+	TrustProxy = boolEnv("TRUST_PROXY", false)
+	CommentEditWindow = durationEnv("COMMENT_EDIT_WINDOW", 15*time.Minute)
+	Reactions = listEnv("REACTIONS", []string{"like", "love", "laugh", "wow", "sad", "angry"})
+	UploadDriver = stringEnv("UPLOAD_DRIVER", "local")
+	UploadDir = stringEnv("UPLOAD_DIR", "uploads")
+	UploadBaseURL = strings.TrimSuffix(os.Getenv("UPLOAD_BASE_URL"), "/")
+	UploadMaxSize = int64(intEnv("UPLOAD_MAX_SIZE", 5<<20))
+	UploadMaxDimension = intEnv("UPLOAD_MAX_DIMENSION", 4096)
+	S3Endpoint = strings.TrimSuffix(os.Getenv("S3_ENDPOINT"), "/")
+	S3Region = stringEnv("S3_REGION", "us-east-1")
+	S3Bucket = os.Getenv("S3_BUCKET")
+	S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+	S3SecretKey = os.Getenv("S3_SECRET_KEY")
+}
+
+func stringEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func intEnv(key string, fallback int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+func boolEnv(key string, fallback bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+func listEnv(key string, fallback []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	list := []string{}
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	if len(list) == 0 {
+		return fallback
+	}
+	return list
 }
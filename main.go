@@ -12,8 +12,8 @@ import (
 	"github.com/ashalfarhan/realworld/config"
 	"github.com/ashalfarhan/realworld/persistence"
 	"github.com/ashalfarhan/realworld/service"
+	"github.com/ashalfarhan/realworld/storage"
 	"github.com/ashalfarhan/realworld/utils/logger"
-	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
@@ -25,9 +25,10 @@ func init() {
 
 func main() {
 	db := persistence.Connect()
-	store := cache.Init()
-	services := service.InitService(db, store)
-	server := api.InitServer(services)
+	c := cache.Init()
+	blobs := storage.Init()
+	services := service.InitService(db, c.Store, blobs)
+	server := api.InitServer(services, c.Store.RateStore)
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 	logrus.Println("Booting up the server...")
@@ -35,7 +36,7 @@ func main() {
 	go func() {
 		if err := server.ListenAndServe(); err != nil {
 			logrus.Errorln("Failed to start the server:", err)
-			if err = cleanup(store, db); err != nil {
+			if err = cleanup(c, db); err != nil {
 				logrus.Errorln("Failed to cleanup:", err)
 			}
 		}
@@ -46,7 +47,7 @@ func main() {
 	logrus.Println("Gracefully shutdown...")
 
 	defer func() {
-		if err := cleanup(store, db); err != nil {
+		if err := cleanup(c, db); err != nil {
 			logrus.Errorln("Failed to cleanup:", err)
 		}
 	}()
@@ -56,9 +57,9 @@ func main() {
 	}
 }
 
-func cleanup(store *redis.Client, db *sqlx.DB) error {
-	if err := store.Close(); err != nil && err != redis.ErrClosed {
-		return fmt.Errorf("failed to close redis: %w", err)
+func cleanup(c *cache.Cache, db *sqlx.DB) error {
+	if err := c.Close(); err != nil {
+		return fmt.Errorf("failed to close cache: %w", err)
 	}
 	if err := db.Close(); err != nil {
 		return fmt.Errorf("failed to close postgres: %w", err)
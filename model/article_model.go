@@ -3,49 +3,94 @@ package model
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type Article struct {
-	ID             string     `json:"id" db:"id"`
-	Slug           string     `json:"slug" db:"slug"`
-	Title          string     `json:"title" db:"title"`
-	Description    string     `json:"description" db:"description"`
-	Body           string     `json:"body" db:"body"`
-	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
-	UpdatedAt      time.Time  `json:"updatedAt" db:"updated_at"`
-	TagList        []string   `json:"tagList"`
-	AuthorUsername string     `json:"authorUsername" db:"author_username"`
-	Favorited      bool       `json:"favorited" db:"favorited"`
-	FavoritesCount int        `json:"favoritesCount" db:"favorites_count"`
-	Author         *ProfileRs `json:"author" db:"author"`
+	ID              string         `json:"id" db:"id"`
+	Slug            string         `json:"slug" db:"slug"`
+	Title           string         `json:"title" db:"title"`
+	Description     string         `json:"description" db:"description"`
+	Body            string         `json:"body" db:"body"`
+	BodyHTML        string         `json:"bodyHtml,omitempty" db:"-"`
+	CreatedAt       time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time      `json:"updatedAt" db:"updated_at"`
+	TagList         []string       `json:"tagList"`
+	Mentions        pq.StringArray `json:"mentions" db:"mentions"`
+	AuthorUsername  string         `json:"authorUsername" db:"author_username"`
+	Favorited       bool           `json:"favorited" db:"favorited"`
+	Bookmarked      bool           `json:"bookmarked" db:"bookmarked"`
+	FavoritesCount  int            `json:"favoritesCount" db:"favorites_count"`
+	Author          *ProfileRs     `json:"author" db:"author"`
+	Reactions       map[string]int `json:"reactions" db:"-"`
+	ViewerReactions []string       `json:"viewerReactions" db:"-"`
+	Version         int            `json:"version" db:"version"`
+	ArticleStats
 }
 
 type ArticleRs struct {
-	Slug           string     `json:"slug"`
-	Title          string     `json:"title"`
-	Description    string     `json:"description"`
-	Body           string     `json:"body"`
-	CreatedAt      time.Time  `json:"createdAt"`
-	UpdatedAt      time.Time  `json:"updatedAt"`
-	TagList        []string   `json:"tagList"`
-	Favorited      bool       `json:"favorited"`
-	FavoritesCount int        `json:"favoritesCount"`
-	Author         *ProfileRs `json:"author"`
+	Slug            string         `json:"slug"`
+	Title           string         `json:"title"`
+	Description     string         `json:"description"`
+	Body            string         `json:"body"`
+	BodyHTML        string         `json:"bodyHtml,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	TagList         []string       `json:"tagList"`
+	Mentions        []string       `json:"mentions"`
+	Favorited       bool           `json:"favorited"`
+	Bookmarked      bool           `json:"bookmarked"`
+	FavoritesCount  int            `json:"favoritesCount"`
+	Author          *ProfileRs     `json:"author"`
+	Reactions       map[string]int `json:"reactions"`
+	ViewerReactions []string       `json:"viewerReactions"`
+	Version         int            `json:"version"`
+	ArticleStats
 }
 
 func (a Article) Serialize() *ArticleRs {
 	return &ArticleRs{
-		Slug:           a.Slug,
-		Title:          a.Title,
-		Description:    a.Description,
-		Body:           a.Body,
-		CreatedAt:      a.CreatedAt,
-		UpdatedAt:      a.UpdatedAt,
-		TagList:        a.TagList,
-		Favorited:      a.Favorited,
-		FavoritesCount: a.FavoritesCount,
-		Author:         a.Author,
+		Slug:            a.Slug,
+		Title:           a.Title,
+		Description:     a.Description,
+		Body:            a.Body,
+		CreatedAt:       a.CreatedAt,
+		UpdatedAt:       a.UpdatedAt,
+		TagList:         a.TagList,
+		Mentions:        a.Mentions,
+		Favorited:       a.Favorited,
+		Bookmarked:      a.Bookmarked,
+		FavoritesCount:  a.FavoritesCount,
+		ArticleStats:    a.ArticleStats,
+		Author:          a.Author,
+		Reactions:       a.Reactions,
+		ViewerReactions: a.ViewerReactions,
+		Version:         a.Version,
+	}
+}
+
+// Computed from the body when it is written, so lists do not have to
+type ArticleStats struct {
+	WordCount          int    `json:"wordCount" db:"word_count"`
+	ReadingTimeMinutes int    `json:"readingTimeMinutes" db:"reading_time_minutes"`
+	Excerpt            string `json:"excerpt" db:"excerpt"`
+}
+
+// Body is always returned, the rendered HTML only when it is asked for
+func (a Article) SerializeFormat(format string) *ArticleRs {
+	rs := a.Serialize()
+	if format == FormatHTML {
+		rs.BodyHTML = a.BodyHTML
 	}
+	return rs
+}
+
+func (a *Article) SetReactions(r *Reactions) {
+	if r == nil {
+		r = &Reactions{Counts: map[string]int{}, Viewer: []string{}}
+	}
+	a.Reactions, a.ViewerReactions = r.Counts, r.Viewer
 }
 
 func (a Article) MarshalBinary() ([]byte, error) {
@@ -66,6 +111,25 @@ func (as Articles) Serialize() []*ArticleRs {
 	return ars
 }
 
+// The latest change among the articles, zero for an empty list
+func (as Articles) LastUpdatedAt() time.Time {
+	var last time.Time
+	for _, a := range as {
+		if a.UpdatedAt.After(last) {
+			last = a.UpdatedAt
+		}
+	}
+	return last
+}
+
+func (as Articles) SerializeFormat(format string) []*ArticleRs {
+	ars := []*ArticleRs{}
+	for _, a := range as {
+		ars = append(ars, a.SerializeFormat(format))
+	}
+	return ars
+}
+
 func (a Articles) MarshalBinary() ([]byte, error) {
 	return json.Marshal(a)
 }
@@ -74,11 +138,28 @@ func (a *Articles) UnmarshalBinary(data []byte) error {
 	return json.Unmarshal(data, a)
 }
 
+// Formats a body can be returned in, the raw markdown is always included
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+const (
+	FeedModeAuthors = "authors"
+	FeedModeTags    = "tags"
+	FeedModeAll     = "all"
+)
+
 type FindArticlesArgs struct {
-	Tag       string `db:"tag"`
-	Author    string `db:"author_username"`
-	Username  string `db:"username"`
-	Favorited string `db:"favorited_by"`
-	Limit     int    `validate:"min=1,max=25" db:"limit"`
-	Offset    int    `validate:"min=0" db:"offset"`
-}
\ No newline at end of file
+	Tag        string `db:"tag"`
+	Author     string `db:"author_username"`
+	Username   string `db:"username"`
+	Favorited  string `db:"favorited_by"`
+	Feed       bool   `db:"-"`
+	Bookmarked bool   `db:"-"`
+	Collection string `validate:"max=64" db:"collection"`
+	FeedMode   string `validate:"omitempty,oneof=authors tags all"`
+	Format     string `validate:"omitempty,oneof=markdown html" db:"-"`
+	Limit      int    `validate:"min=1,max=25" db:"limit"`
+	Offset     int    `validate:"min=0" db:"offset"`
+}
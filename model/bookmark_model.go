@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// A named folder of the private reading list
+type BookmarkCollection struct {
+	ID             string    `json:"-" db:"id"`
+	Name           string    `json:"name" db:"name"`
+	BookmarksCount int       `json:"bookmarksCount" db:"bookmarks_count"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+}
+
+type BookmarkFields struct {
+	Collection string `json:"collection" validate:"max=64"`
+}
+
+type BookmarkDto struct {
+	Bookmark *BookmarkFields `json:"bookmark" validate:"required"`
+}
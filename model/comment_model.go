@@ -3,16 +3,39 @@ package model
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/lib/pq"
 )
 
+// Placeholder body for a removed comment that still has replies
+const DeletedCommentBody = "[deleted]"
+
 type Comment struct {
-	ID             string     `json:"id" db:"id"`
-	Body           string     `json:"body" db:"body"`
-	ArticleID      string     `json:"-" db:"article_id"`
-	AuthorUsername string     `json:"-" db:"author_username"`
-	Author         *ProfileRs `json:"author" db:"author"`
-	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
-	UpdatedAt      time.Time  `json:"updatedAt" db:"updated_at"`
+	ID              string         `json:"id" db:"id"`
+	Body            string         `json:"body" db:"body"`
+	BodyHTML        string         `json:"bodyHtml,omitempty" db:"-"`
+	ArticleID       string         `json:"-" db:"article_id"`
+	ParentID        *string        `json:"parentId" db:"parent_id"`
+	AuthorUsername  string         `json:"-" db:"author_username"`
+	Author          *ProfileRs     `json:"author" db:"author"`
+	Deleted         bool           `json:"deleted,omitempty" db:"-"`
+	DeletedAt       *time.Time     `json:"-" db:"deleted_at"`
+	Depth           int            `json:"-" db:"depth"`
+	Edited          bool           `json:"edited" db:"edited"`
+	Mentions        pq.StringArray `json:"mentions" db:"mentions"`
+	RepliesCount    int            `json:"repliesCount" db:"replies_count"`
+	Reactions       map[string]int `json:"reactions" db:"-"`
+	ViewerReactions []string       `json:"viewerReactions" db:"-"`
+	Replies         Comments       `json:"replies,omitempty" db:"-"`
+	CreatedAt       time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time      `json:"updatedAt" db:"updated_at"`
+}
+
+func (c *Comment) SetReactions(r *Reactions) {
+	if r == nil {
+		r = &Reactions{Counts: map[string]int{}, Viewer: []string{}}
+	}
+	c.Reactions, c.ViewerReactions = r.Counts, r.Viewer
 }
 
 type Comments []*Comment
@@ -24,3 +47,47 @@ func (a Comments) MarshalBinary() ([]byte, error) {
 func (a *Comments) UnmarshalBinary(data []byte) error {
 	return json.Unmarshal(data, a)
 }
+
+// Nest a flat list of comments into threads.
+// The list must be ordered so that a parent always comes before its replies,
+// replies whose parent is missing from the list are dropped.
+func (a Comments) Thread() Comments {
+	roots := Comments{}
+	byID := make(map[string]*Comment, len(a))
+	for _, c := range a {
+		if c.DeletedAt != nil {
+			c.Deleted = true
+			c.Body = DeletedCommentBody
+			c.Author = nil
+			c.Mentions = pq.StringArray{}
+		}
+		byID[c.ID] = c
+		if c.ParentID == nil {
+			roots = append(roots, c)
+			continue
+		}
+		if p, ok := byID[*c.ParentID]; ok {
+			p.Replies = append(p.Replies, c)
+		}
+	}
+	return roots
+}
+
+// A prior version of a comment body, kept on every edit
+type CommentEdit struct {
+	Body     string    `json:"body" db:"body"`
+	EditedAt time.Time `json:"editedAt" db:"edited_at"`
+}
+
+// Top level comments are paged with a keyset cursor on (created_at, id),
+// each page carries the replies of its comments.
+type FindCommentsArgs struct {
+	ArticleID  string    `db:"article_id"`
+	Username   string    `db:"username"`
+	Depth      int       `validate:"min=1,max=10" db:"depth"`
+	Limit      int       `validate:"min=1,max=50" db:"limit"`
+	HasCursor  bool      `db:"has_cursor"`
+	CursorTime time.Time `db:"cursor_time"`
+	CursorID   string    `db:"cursor_id"`
+	Format     string    `validate:"omitempty,oneof=markdown html" db:"-"`
+}
@@ -2,10 +2,11 @@ package model
 
 type CreateArticleFields struct {
 	Title       string   `json:"title" validate:"required,max=255"`
-	Description string   `json:"description" validate:"required,max=255"`
+	Description string   `json:"description" validate:"max=255"`
 	Body        string   `json:"body" validate:"required"`
 	TagList     []string `json:"tagList" validate:"omitempty,unique"`
 	Slug        string
+	Stats       ArticleStats `json:"-"`
 }
 
 type CreateArticleDto struct {
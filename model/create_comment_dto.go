@@ -1,9 +1,18 @@
 package model
 
 type CreateCommentFields struct {
-	Body string `json:"body" validate:"required"`
+	Body     string  `json:"body" validate:"required"`
+	ParentID *string `json:"parentId" validate:"omitempty,uuid"`
 }
 
 type CreateCommentDto struct {
 	Comment *CreateCommentFields `json:"comment" validate:"required"`
 }
+
+type UpdateCommentFields struct {
+	Body string `json:"body" validate:"required"`
+}
+
+type UpdateCommentDto struct {
+	Comment *UpdateCommentFields `json:"comment" validate:"required"`
+}
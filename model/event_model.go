@@ -0,0 +1,48 @@
+package model
+
+import "encoding/json"
+
+const (
+	EventComment      = "comment"
+	EventArticle      = "article"
+	EventNotification = "notification"
+)
+
+// A real-time update pushed to the subscribers of a channel
+type Event struct {
+	// The same for every channel an event is published to,
+	// a subscriber of several of them gets it once
+	ID    string          `json:"id"`
+	Type  string          `json:"type"`
+	Actor string          `json:"actor"`
+	Data  json.RawMessage `json:"data"`
+}
+
+func (e Event) MarshalBinary() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (e *Event) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, e)
+}
+
+// Private updates of a user, like notifications
+func UserChannel(username string) string {
+	return "user:" + username
+}
+
+// New articles of an author, followers build their feed from it
+func AuthorChannel(username string) string {
+	return "author:" + username
+}
+
+// New articles with a tag, anyone can follow a tag,
+// so only the articles of public authors are published here
+func TagChannel(name string) string {
+	return "tag:" + name
+}
+
+// New comments on an article
+func ArticleChannel(id string) string {
+	return "article:" + id
+}
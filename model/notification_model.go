@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	NotificationFollow   = "follow"
+	NotificationFavorite = "favorite"
+	NotificationComment  = "comment"
+	NotificationMention  = "mention"
+)
+
+// How many of the latest actors are listed on a grouped notification
+const notificationActorsShown = 3
+
+// Repeated events of the same type on the same article are grouped,
+// read and unread events are kept apart.
+// The ID is the one of the latest event in the group.
+type Notification struct {
+	ID           string         `json:"id" db:"id"`
+	Type         string         `json:"type" db:"type"`
+	ArticleSlug  *string        `json:"articleSlug,omitempty" db:"article_slug"`
+	ArticleTitle *string        `json:"articleTitle,omitempty" db:"article_title"`
+	Actors       pq.StringArray `json:"actors" db:"actors"`
+	ActorsCount  int            `json:"actorsCount" db:"actors_count"`
+	Read         bool           `json:"read" db:"read"`
+	CreatedAt    time.Time      `json:"createdAt" db:"created_at"`
+}
+
+// Keep the latest few distinct actors, the query lists all of them newest-first
+func (n *Notification) TrimActors() {
+	seen := make(map[string]bool)
+	actors := pq.StringArray{}
+	for _, a := range n.Actors {
+		if seen[a] {
+			continue
+		}
+		seen[a] = true
+		if actors = append(actors, a); len(actors) == notificationActorsShown {
+			break
+		}
+	}
+	n.Actors = actors
+}
+
+type CreateNotificationArgs struct {
+	Recipient string  `json:"-" db:"recipient_username"`
+	Actor     string  `json:"actor" db:"actor_username"`
+	Type      string  `json:"type" db:"type"`
+	ArticleID *string `json:"articleId,omitempty" db:"article_id"`
+	CommentID *string `json:"commentId,omitempty" db:"comment_id"`
+}
+
+type FindNotificationsArgs struct {
+	Username string `db:"username"`
+	Limit    int    `validate:"min=1,max=50" db:"limit"`
+	Offset   int    `validate:"min=0" db:"offset"`
+}
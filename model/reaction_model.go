@@ -0,0 +1,40 @@
+package model
+
+// A reaction targets either an article or a comment, never both
+type Reaction struct {
+	Username  string  `db:"username"`
+	ArticleID *string `db:"article_id"`
+	CommentID *string `db:"comment_id"`
+	Reaction  string  `db:"reaction"`
+}
+
+// Aggregated reactions of one kind on a target
+type ReactionCount struct {
+	TargetID string `db:"target_id"`
+	Reaction string `db:"reaction"`
+	Count    int    `db:"count"`
+	Reacted  bool   `db:"reacted"`
+}
+
+// Reactions of one target keyed by reaction, and the ones the viewer made
+type Reactions struct {
+	Counts map[string]int
+	Viewer []string
+}
+
+// Group aggregated counts by their target
+func GroupReactions(counts []*ReactionCount) map[string]*Reactions {
+	grouped := make(map[string]*Reactions)
+	for _, c := range counts {
+		r, ok := grouped[c.TargetID]
+		if !ok {
+			r = &Reactions{Counts: map[string]int{}, Viewer: []string{}}
+			grouped[c.TargetID] = r
+		}
+		r.Counts[c.Reaction] = c.Count
+		if c.Reacted {
+			r.Viewer = append(r.Viewer, c.Reaction)
+		}
+	}
+	return grouped
+}
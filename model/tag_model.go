@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type Tag struct {
+	Name          string     `json:"name" db:"name"`
+	Description   NullString `json:"description" db:"description"`
+	ArticlesCount int        `json:"articlesCount" db:"articles_count"`
+	Following     bool       `json:"following" db:"following"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+const (
+	TagSortName    = "name"
+	TagSortPopular = "popular"
+)
+
+type FindTagsArgs struct {
+	Sort     string `validate:"oneof=name popular"`
+	Username string `db:"username"`
+	Limit    int    `validate:"min=1,max=100" db:"limit"`
+}
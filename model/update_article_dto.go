@@ -1,10 +1,16 @@
 package model
 
 type UpdateArticleFields struct {
-	Title       *string `json:"title" validate:"omitempty,max=255"`
-	Body        *string `json:"body" validate:"omitempty,max=255"`
-	Description *string `json:"description" validate:"omitempty,max=255"`
+	Title       *string   `json:"title" validate:"omitempty,max=255"`
+	Body        *string   `json:"body" validate:"omitempty,max=255"`
+	Description *string   `json:"description" validate:"omitempty,max=255"`
+	TagList     *[]string `json:"tagList" validate:"omitempty,unique"`
 	Slug        *string
+	Stats       *ArticleStats `json:"-"`
+	// The version the change was made on, a stale one is a conflict
+	Version *int `json:"version" validate:"omitempty,min=1"`
+	// Versions named by If-Match, nil when the header is not set
+	IfMatch []int `json:"-"`
 }
 
 type UpdateArticleDto struct {
@@ -6,6 +6,11 @@ type UpdateUserFields struct {
 	Password *string    `json:"password" validate:"omitempty,min=8,max=64"`
 	Image    NullString `json:"image" validate:"url"`
 	Bio      NullString `json:"bio" validate:"max=255"`
+	Private  *bool      `json:"private"`
+	// The version the change was made on, a stale one is a conflict
+	Version *int `json:"version" validate:"omitempty,min=1"`
+	// Versions named by If-Match, nil when the header is not set
+	IfMatch []int `json:"-"`
 }
 
 type UpdateUserDto struct {
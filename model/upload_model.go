@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+const (
+	UploadAvatar  = "avatar"
+	UploadArticle = "article"
+)
+
+// An uploaded image, the blobs live in the storage.BlobStore
+type Upload struct {
+	ID           string    `json:"id" db:"id"`
+	Username     string    `json:"-" db:"username"`
+	Purpose      string    `json:"purpose" db:"purpose"`
+	Key          string    `json:"-" db:"key"`
+	ThumbnailKey *string   `json:"-" db:"thumbnail_key"`
+	URL          string    `json:"url" db:"-"`
+	ThumbnailURL *string   `json:"thumbnailUrl" db:"-"`
+	ContentType  string    `json:"contentType" db:"content_type"`
+	Size         int64     `json:"size" db:"size"`
+	Width        int       `json:"width" db:"width"`
+	Height       int       `json:"height" db:"height"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+}
+
+type CreateUploadFields struct {
+	Purpose string `validate:"oneof=avatar article"`
+}
@@ -13,8 +13,11 @@ type User struct {
 	Username  string     `json:"username" db:"username"`
 	Bio       NullString `json:"bio" db:"bio"`
 	Image     NullString `json:"image" db:"image"`
+	Private   bool       `json:"private" db:"private"`
+	Moderator bool       `json:"-" db:"moderator"`
 	CreatedAt time.Time  `json:"-" db:"created_at"`
 	UpdatedAt time.Time  `json:"-" db:"updated_at"`
+	Version   int        `json:"-" db:"version"`
 }
 
 func (u *User) ValidatePassword(incPass string) bool {
@@ -26,7 +29,9 @@ type UserRs struct {
 	Bio      NullString `json:"bio"`
 	Image    NullString `json:"image"`
 	Email    string     `json:"email"`
+	Private  bool       `json:"private"`
 	Token    string     `json:"token,omitempty"`
+	Version  int        `json:"version"`
 }
 
 func (u *User) Serialize(token string) *UserRs {
@@ -35,15 +40,23 @@ func (u *User) Serialize(token string) *UserRs {
 		Username: u.Username,
 		Bio:      u.Bio,
 		Image:    u.Image,
+		Private:  u.Private,
 		Token:    token,
+		Version:  u.Version,
 	}
 }
 
 type ProfileRs struct {
-	Username  string     `json:"username"`
-	Bio       NullString `json:"bio"`
-	Image     NullString `json:"image"`
-	Following bool       `json:"following"`
+	Username        string     `json:"username"`
+	Bio             NullString `json:"bio"`
+	Image           NullString `json:"image"`
+	Following       bool       `json:"following"`
+	FollowersCount  *int       `json:"followersCount,omitempty"`
+	FollowingCount  *int       `json:"followingCount,omitempty"`
+	Private         bool       `json:"private,omitempty"`
+	FollowRequested bool       `json:"followRequested,omitempty"`
+	Blocking        bool       `json:"blocking,omitempty"`
+	Muting          bool       `json:"muting,omitempty"`
 }
 
 func (u *User) Profile(following bool) *ProfileRs {
@@ -52,6 +65,7 @@ func (u *User) Profile(following bool) *ProfileRs {
 		Bio:       u.Bio,
 		Image:     u.Image,
 		Following: following,
+		Private:   u.Private,
 	}
 }
 
@@ -59,3 +73,10 @@ type FindUserArg struct {
 	Email    string
 	Username string
 }
+
+type FindFollowsArgs struct {
+	Username string `db:"username"`
+	Viewer   string `db:"viewer"`
+	Limit    int    `validate:"min=1,max=50" db:"limit"`
+	Offset   int    `validate:"min=0" db:"offset"`
+}
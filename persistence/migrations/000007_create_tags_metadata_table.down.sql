@@ -0,0 +1,2 @@
+ALTER TABLE article_tags DROP CONSTRAINT IF EXISTS fk_article_tags_tag;
+DROP TABLE IF EXISTS tags;
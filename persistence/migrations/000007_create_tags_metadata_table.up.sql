@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS tags (
+    name            VARCHAR(255) PRIMARY KEY,
+    description     TEXT,
+    articles_count  INT NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO tags (name, articles_count)
+SELECT at.tag_name, COUNT(*) FROM article_tags as at
+GROUP BY at.tag_name
+ON CONFLICT (name) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_tags_articles_count ON tags (articles_count DESC);
+
+ALTER TABLE article_tags
+    ADD CONSTRAINT fk_article_tags_tag
+        FOREIGN KEY (tag_name)
+        REFERENCES tags(name)
+        ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_article_tags_tag_name;
+DROP TABLE IF EXISTS tag_followings;
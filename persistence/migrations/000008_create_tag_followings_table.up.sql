@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS tag_followings (
+    username    VARCHAR(255) NOT NULL,
+    tag_name    VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(username, tag_name),
+    CONSTRAINT fk_tag_followings_user
+        FOREIGN KEY (username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_tag_followings_tag
+        FOREIGN KEY (tag_name)
+        REFERENCES tags(name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag_name ON article_tags (tag_name);
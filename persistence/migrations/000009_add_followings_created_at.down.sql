@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_followings_following;
+DROP INDEX IF EXISTS idx_followings_follower;
+ALTER TABLE followings DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,5 @@
+ALTER TABLE followings
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_followings_follower ON followings (follower_username, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_followings_following ON followings (following_username, created_at DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_username    VARCHAR(255) NOT NULL,
+    blocked_username    VARCHAR(255) NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(blocker_username, blocked_username),
+    CONSTRAINT fk_user_blocks_blocker
+        FOREIGN KEY (blocker_username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_user_blocks_blocked
+        FOREIGN KEY (blocked_username)
+        REFERENCES users(username) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks (blocked_username);
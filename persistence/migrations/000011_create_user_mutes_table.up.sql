@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_mutes (
+    muter_username  VARCHAR(255) NOT NULL,
+    muted_username  VARCHAR(255) NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(muter_username, muted_username),
+    CONSTRAINT fk_user_mutes_muter
+        FOREIGN KEY (muter_username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_user_mutes_muted
+        FOREIGN KEY (muted_username)
+        REFERENCES users(username) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS private BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS follow_requests (
+    requester_username  VARCHAR(255) NOT NULL,
+    target_username     VARCHAR(255) NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(requester_username, target_username),
+    CONSTRAINT fk_follow_requests_requester
+        FOREIGN KEY (requester_username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_follow_requests_target
+        FOREIGN KEY (target_username)
+        REFERENCES users(username) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_follow_requests_target ON follow_requests (target_username, created_at DESC);
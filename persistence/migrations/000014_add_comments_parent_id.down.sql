@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_article_comments_parent;
+DROP INDEX IF EXISTS idx_article_comments_article_parent;
+ALTER TABLE article_comments
+    DROP CONSTRAINT IF EXISTS fk_article_comments_parent,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS parent_id;
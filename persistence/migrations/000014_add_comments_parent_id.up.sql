@@ -0,0 +1,10 @@
+ALTER TABLE article_comments
+    ADD COLUMN IF NOT EXISTS parent_id UUID NULL,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL,
+    ADD CONSTRAINT fk_article_comments_parent
+        FOREIGN KEY (parent_id)
+        REFERENCES article_comments(id)
+        ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_article_comments_article_parent ON article_comments(article_id, parent_id);
+CREATE INDEX IF NOT EXISTS idx_article_comments_parent ON article_comments(parent_id);
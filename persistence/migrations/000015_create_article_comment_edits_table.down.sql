@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS article_comment_edits;
+ALTER TABLE users DROP COLUMN IF EXISTS moderator;
@@ -0,0 +1,14 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS moderator BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS article_comment_edits (
+    id          SERIAL PRIMARY KEY,
+    comment_id  UUID NOT NULL,
+    body        TEXT NOT NULL,
+    edited_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_article_comment_edits_comment
+        FOREIGN KEY (comment_id)
+        REFERENCES article_comments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_comment_edits_comment ON article_comment_edits(comment_id);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS reactions (
+    id          SERIAL PRIMARY KEY,
+    username    VARCHAR(255) NOT NULL,
+    article_id  UUID NULL,
+    comment_id  UUID NULL,
+    reaction    VARCHAR(32) NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_reactions_target
+        CHECK ((article_id IS NULL) <> (comment_id IS NULL)),
+    CONSTRAINT fk_reactions_user
+        FOREIGN KEY (username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_reactions_article
+        FOREIGN KEY (article_id)
+        REFERENCES articles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reactions_comment
+        FOREIGN KEY (comment_id)
+        REFERENCES article_comments(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS reactions_article_key
+    ON reactions(article_id, username, reaction) WHERE article_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS reactions_comment_key
+    ON reactions(comment_id, username, reaction) WHERE comment_id IS NOT NULL;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS bookmark_collections (
+    id          UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    username    VARCHAR(255) NOT NULL,
+    name        VARCHAR(64) NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(username, name),
+    CONSTRAINT fk_bookmark_collections_user
+        FOREIGN KEY (username)
+        REFERENCES users(username) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    username        VARCHAR(255) NOT NULL,
+    article_id      UUID NOT NULL,
+    collection_id   UUID NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(username, article_id),
+    CONSTRAINT fk_bookmarks_user
+        FOREIGN KEY (username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_bookmarks_article
+        FOREIGN KEY (article_id)
+        REFERENCES articles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bookmarks_collection
+        FOREIGN KEY (collection_id)
+        REFERENCES bookmark_collections(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_bookmarks_username_created_at ON bookmarks(username, created_at DESC);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id                  UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    recipient_username  VARCHAR(255) NOT NULL,
+    actor_username      VARCHAR(255) NOT NULL,
+    type                VARCHAR(32) NOT NULL,
+    article_id          UUID NULL,
+    comment_id          UUID NULL,
+    read_at             TIMESTAMP NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_notifications_recipient
+        FOREIGN KEY (recipient_username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_notifications_actor
+        FOREIGN KEY (actor_username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_notifications_article
+        FOREIGN KEY (article_id)
+        REFERENCES articles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_notifications_comment
+        FOREIGN KEY (comment_id)
+        REFERENCES article_comments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_created_at ON notifications(recipient_username, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_unread ON notifications(recipient_username) WHERE read_at IS NULL;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS mentions (
+    id          SERIAL PRIMARY KEY,
+    username    VARCHAR(255) NOT NULL,
+    article_id  UUID NOT NULL,
+    comment_id  UUID NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_mentions_user
+        FOREIGN KEY (username)
+        REFERENCES users(username) ON DELETE CASCADE,
+    CONSTRAINT fk_mentions_article
+        FOREIGN KEY (article_id)
+        REFERENCES articles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_mentions_comment
+        FOREIGN KEY (comment_id)
+        REFERENCES article_comments(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS mentions_article_key
+    ON mentions(article_id, username) WHERE comment_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS mentions_comment_key
+    ON mentions(comment_id, username) WHERE comment_id IS NOT NULL;
@@ -0,0 +1,18 @@
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS word_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS reading_time_minutes INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS excerpt TEXT NOT NULL DEFAULT '';
+
+-- Existing articles get an estimate from their raw markdown,
+-- the exact values are computed on their next update.
+UPDATE articles SET
+    word_count = CASE
+        WHEN btrim(body) = '' THEN 0
+        ELSE array_length(regexp_split_to_array(btrim(body), '\s+'), 1)
+    END,
+    excerpt = CASE
+        WHEN description <> '' THEN description
+        ELSE left(regexp_replace(btrim(body), '\s+', ' ', 'g'), 200)
+    END;
+
+UPDATE articles SET reading_time_minutes = CEIL(word_count / 200.0);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS uploads (
+    id              UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    username        VARCHAR(255) NOT NULL,
+    purpose         VARCHAR(32) NOT NULL,
+    key             VARCHAR(255) NOT NULL UNIQUE,
+    thumbnail_key   VARCHAR(255) NULL,
+    content_type    VARCHAR(64) NOT NULL,
+    size            BIGINT NOT NULL,
+    width           INT NOT NULL,
+    height          INT NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_uploads_user
+        FOREIGN KEY (username)
+        REFERENCES users(username) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_username_created_at ON uploads(username, created_at DESC);
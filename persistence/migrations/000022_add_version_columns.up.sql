@@ -0,0 +1,4 @@
+-- Bumped on every update, writers send the version they read
+-- and lose when someone else updated in between.
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
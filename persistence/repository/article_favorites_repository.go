@@ -12,9 +12,10 @@ type ArticleFavoritesRepoImpl struct {
 
 type ArticleFavoritesRepository interface {
 	InsertOne(context.Context, string, string) error
-	Delete(context.Context, string, string) error
+	Delete(context.Context, string, string) (int64, error)
 	FindOneByIDs(context.Context, string, string) (*string, error)
 	CountFavorites(context.Context, string) (int, error)
+	FindArticleIDsByUsername(context.Context, string) ([]string, error)
 }
 
 func (r *ArticleFavoritesRepoImpl) InsertOne(ctx context.Context, username, articleID string) error {
@@ -31,10 +32,11 @@ func (r *ArticleFavoritesRepoImpl) InsertOne(ctx context.Context, username, arti
 	return tx.Commit()
 }
 
-func (r *ArticleFavoritesRepoImpl) Delete(ctx context.Context, username, articleID string) error {
+// Returns how many favorites were removed, 0 when there was none
+func (r *ArticleFavoritesRepoImpl) Delete(ctx context.Context, username, articleID string) (int64, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback()
 
@@ -42,10 +44,15 @@ func (r *ArticleFavoritesRepoImpl) Delete(ctx context.Context, username, article
 	DELETE FROM article_favorites as af
 	WHERE af.username = $1 
 	AND af.article_id = $2`
-	if _, err = tx.ExecContext(ctx, query, username, articleID); err != nil {
-		return err
+	res, err := tx.ExecContext(ctx, query, username, articleID)
+	if err != nil {
+		return 0, err
 	}
-	return tx.Commit()
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return deleted, tx.Commit()
 }
 
 func (r *ArticleFavoritesRepoImpl) FindOneByIDs(ctx context.Context, username, articleID string) (*string, error) {
@@ -70,3 +77,13 @@ func (r *ArticleFavoritesRepoImpl) CountFavorites(ctx context.Context, articleID
 	}
 	return count, nil
 }
+
+// Every article "username" has favorited, to warm the viewer cache
+func (r *ArticleFavoritesRepoImpl) FindArticleIDsByUsername(ctx context.Context, username string) ([]string, error) {
+	ids := []string{}
+	query := "SELECT af.article_id FROM article_favorites as af WHERE af.username = $1"
+	if err := r.db.SelectContext(ctx, &ids, query, username); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
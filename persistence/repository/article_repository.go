@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/ashalfarhan/realworld/model"
 	"github.com/jmoiron/sqlx"
@@ -15,8 +16,9 @@ type ArticleRepository interface {
 	InsertOne(context.Context, *model.CreateArticleFields, string) (*model.Article, error)
 	FindOneBySlug(context.Context, string, string) (*model.Article, error)
 	DeleteBySlug(context.Context, string) error
-	UpdateOneBySlug(context.Context, *model.UpdateArticleFields, *model.Article) error
+	UpdateOneBySlug(context.Context, *model.UpdateArticleFields, *model.Article, *ArticleTagsDiff) error
 	Find(context.Context, *model.FindArticlesArgs) (model.Articles, error)
+	FindSlugsByAuthor(context.Context, string) ([]string, error)
 }
 
 func (r *ArticleRepoImpl) InsertOne(ctx context.Context, d *model.CreateArticleFields, username string) (*model.Article, error) {
@@ -31,12 +33,19 @@ func (r *ArticleRepoImpl) InsertOne(ctx context.Context, d *model.CreateArticleF
 		Body:           d.Body,
 		AuthorUsername: username,
 		Slug:           d.Slug,
+		ArticleStats:   d.Stats,
 	}
 
 	query := `
-	INSERT INTO articles (slug, title, description, body, author_username) 
-	VALUES (:slug, :title, :description, :body, :author_username) 
-	RETURNING id, created_at, updated_at`
+	INSERT INTO articles (
+		slug, title, description, body, author_username,
+		word_count, reading_time_minutes, excerpt
+	) 
+	VALUES (
+		:slug, :title, :description, :body, :author_username,
+		:word_count, :reading_time_minutes, :excerpt
+	) 
+	RETURNING id, created_at, updated_at, version`
 	stmt, err := tx.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -59,14 +68,26 @@ func (r *ArticleRepoImpl) DeleteBySlug(ctx context.Context, slug string) error {
 	}
 	defer tx.Rollback()
 
-	query := "DELETE FROM articles as a WHERE a.slug = $1"
+	// Tags are detached by the cascade, keep their usage count in sync
+	query := `
+	UPDATE tags
+	SET articles_count = tags.articles_count - 1, updated_at = NOW()
+	FROM article_tags as at
+	JOIN articles as a ON a.id = at.article_id
+	WHERE a.slug = $1 AND tags.name = at.tag_name`
+	if _, err = tx.ExecContext(ctx, query, slug); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM articles as a WHERE a.slug = $1"
 	if _, err = tx.ExecContext(ctx, query, slug); err != nil {
 		return err
 	}
 	return tx.Commit()
 }
 
-func (r *ArticleRepoImpl) UpdateOneBySlug(ctx context.Context, d *model.UpdateArticleFields, a *model.Article) error {
+// The tags, when given, change in the same transaction as the article
+func (r *ArticleRepoImpl) UpdateOneBySlug(ctx context.Context, d *model.UpdateArticleFields, a *model.Article, tags *ArticleTagsDiff) error {
 	if v := d.Title; v != nil {
 		a.Title = *v
 	}
@@ -79,14 +100,20 @@ func (r *ArticleRepoImpl) UpdateOneBySlug(ctx context.Context, d *model.UpdateAr
 	if v := d.Description; v != nil {
 		a.Description = *v
 	}
+	if v := d.Stats; v != nil {
+		a.ArticleStats = *v
+	}
 
 	query := `
 	UPDATE articles as a
 	SET 
 		title = :title, slug = :slug,
 		body = :body, description = :description,
-		updated_at = NOW()
-	WHERE a.id = :id`
+		word_count = :word_count, reading_time_minutes = :reading_time_minutes,
+		excerpt = :excerpt, updated_at = NOW(),
+		version = a.version + 1
+	WHERE a.id = :id AND a.version = :version
+	RETURNING a.version, a.updated_at`
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
@@ -99,9 +126,25 @@ func (r *ArticleRepoImpl) UpdateOneBySlug(ctx context.Context, d *model.UpdateAr
 	}
 	defer stmt.Close()
 
-	if _, err := stmt.ExecContext(ctx, a); err != nil {
+	// No row means the version moved on since the article was read
+	if err := stmt.QueryRowxContext(ctx, a).Scan(&a.Version, &a.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrStaleVersion
+		}
 		return err
 	}
+	if tags != nil {
+		if len(tags.Removed) > 0 {
+			if err := deleteArticleTags(ctx, tx, a.ID, tags.Removed); err != nil {
+				return err
+			}
+		}
+		if len(tags.Added) > 0 {
+			if err := insertArticleTags(ctx, tx, tags.Added); err != nil {
+				return err
+			}
+		}
+	}
 	return tx.Commit()
 }
 
@@ -109,17 +152,39 @@ func (r *ArticleRepoImpl) FindOneBySlug(ctx context.Context, username, slug stri
 	query := `
 	SELECT
 		ar.id, ar.author_username, ar.title, ar.description, ar.body, 
-		ar.created_at, ar.updated_at, ar.slug,
+		ar.created_at, ar.updated_at, ar.slug, ar.version,
+		ar.word_count, ar.reading_time_minutes, ar.excerpt,
 		us.username as "author.username", us.bio as "author.bio",
-		us.image as "author.image", COUNT(af.username) as "favorites_count",
-		($1 IN (af.username) IS NOT NULL) as "favorited"
+		us.image as "author.image", us.private as "author.private",
+		(
+			SELECT COUNT(*) FROM article_favorites as af
+			WHERE af.article_id = ar.id
+		) as "favorites_count",
+		EXISTS (
+			SELECT 1 FROM article_favorites as af
+			WHERE af.article_id = ar.id AND af.username = $1
+		) as "favorited",
+		EXISTS (
+			SELECT 1 FROM bookmarks as b
+			WHERE b.article_id = ar.id AND b.username = $1
+		) as "bookmarked",
+		ARRAY(
+			SELECT m.username FROM mentions as m
+			WHERE m.article_id = ar.id AND m.comment_id IS NULL
+			ORDER BY m.username
+		) as "mentions"
 	FROM articles as ar 
 	LEFT JOIN users as us
 		ON us.username = ar.author_username
-	LEFT JOIN article_favorites as af
-		ON af.article_id = ar.id
 	WHERE ar.slug = $2
-	GROUP BY (ar.id, us.username, us.bio, us.image, af.username)`
+	AND (
+		us.private = FALSE
+		OR ar.author_username = $1
+		OR ar.author_username IN (
+			SELECT f.following_username FROM followings as f
+			WHERE f.follower_username = $1
+		)
+	)`
 	a := new(model.Article)
 	if err := r.db.GetContext(ctx, a, query, username, slug); err != nil {
 		return nil, err
@@ -132,17 +197,38 @@ func (r *ArticleRepoImpl) Find(ctx context.Context, p *model.FindArticlesArgs) (
 	query := `
 	SELECT 
 		ar.id, ar.author_username, ar.title, ar.description, ar.body, 
-		ar.created_at, ar.updated_at, ar.slug,
+		ar.created_at, ar.updated_at, ar.slug, ar.version,
+		ar.word_count, ar.reading_time_minutes, ar.excerpt,
 		us.username as "author.username", us.bio as "author.bio",
-		us.image as "author.image", 
-		COUNT(af.username) as "favorites_count", 
-		(:username IN (af.username) IS NOT NULL) as "favorited"
+		us.image as "author.image", us.private as "author.private",
+		(
+			SELECT COUNT(*) FROM article_favorites as af
+			WHERE af.article_id = ar.id
+		) as "favorites_count",
+		EXISTS (
+			SELECT 1 FROM article_favorites as af
+			WHERE af.article_id = ar.id AND af.username = :username
+		) as "favorited",
+		EXISTS (
+			SELECT 1 FROM bookmarks as b
+			WHERE b.article_id = ar.id AND b.username = :username
+		) as "bookmarked",
+		ARRAY(
+			SELECT m.username FROM mentions as m
+			WHERE m.article_id = ar.id AND m.comment_id IS NULL
+			ORDER BY m.username
+		) as "mentions"
 	FROM articles as ar
 	LEFT JOIN users as us
 		ON us.username = ar.author_username
-	LEFT JOIN article_favorites as af
-		ON af.article_id = ar.id
-	WHERE 1 = 1`
+	WHERE (
+		us.private = FALSE
+		OR ar.author_username = :username
+		OR ar.author_username IN (
+			SELECT f.following_username FROM followings as f
+			WHERE f.follower_username = :username
+		)
+	)`
 
 	if p.Author != "" {
 		query += `
@@ -167,16 +253,75 @@ func (r *ArticleRepoImpl) Find(ctx context.Context, p *model.FindArticlesArgs) (
 		)`
 	}
 
-	if p.Username != "" {
+	if p.Bookmarked {
+		query += `
+		AND ar.id IN (
+			SELECT b.article_id
+			FROM bookmarks as b
+			LEFT JOIN bookmark_collections as bc
+				ON bc.id = b.collection_id
+			WHERE b.username = :username`
+		if p.Collection != "" {
+			query += `
+			AND bc.name = :collection`
+		}
 		query += `
-		AND ar.author_username IN (
-			SELECT f.following_username 
+		)`
+	}
+
+	if p.Feed {
+		followedAuthors := `
+		ar.author_username IN (
+			SELECT f.following_username
 			FROM followings as f
 			WHERE f.follower_username = :username
 		)`
+		followedTags := `
+		ar.id IN (
+			SELECT at.article_id
+			FROM article_tags as at
+			JOIN tag_followings as tf
+				ON tf.tag_name = at.tag_name
+			WHERE tf.username = :username
+		)`
+
+		switch p.FeedMode {
+		case model.FeedModeTags:
+			query += " AND " + followedTags
+		case model.FeedModeAll:
+			query += " AND (" + followedAuthors + " OR " + followedTags + ")"
+		default:
+			query += " AND " + followedAuthors
+		}
+
+		// Blocked (either way) and muted authors never show up in the feed
+		query += `
+		AND ar.author_username NOT IN (
+			SELECT ub.blocked_username
+			FROM user_blocks as ub
+			WHERE ub.blocker_username = :username
+			UNION
+			SELECT ub.blocker_username
+			FROM user_blocks as ub
+			WHERE ub.blocked_username = :username
+			UNION
+			SELECT um.muted_username
+			FROM user_mutes as um
+			WHERE um.muter_username = :username
+		)`
 	}
 
-	query += " GROUP BY (ar.id, us.username, us.bio, us.image, af.username) ORDER BY ar.created_at DESC LIMIT :limit OFFSET :offset"
+	if p.Bookmarked {
+		// Most recently bookmarked first
+		query += `
+		ORDER BY (
+			SELECT b.created_at FROM bookmarks as b
+			WHERE b.article_id = ar.id AND b.username = :username
+		) DESC`
+	} else {
+		query += " ORDER BY ar.created_at DESC"
+	}
+	query += " LIMIT :limit OFFSET :offset"
 	stmt, err := r.db.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -188,3 +333,12 @@ func (r *ArticleRepoImpl) Find(ctx context.Context, p *model.FindArticlesArgs) (
 	}
 	return articles, nil
 }
+
+func (r *ArticleRepoImpl) FindSlugsByAuthor(ctx context.Context, username string) ([]string, error) {
+	slugs := []string{}
+	query := "SELECT ar.slug FROM articles as ar WHERE ar.author_username = $1"
+	if err := r.db.SelectContext(ctx, &slugs, query, username); err != nil {
+		return nil, err
+	}
+	return slugs, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type ArticleTagsRepo struct {
@@ -21,6 +22,12 @@ type InsertArticleTagsArgs struct {
 	TagName   string `db:"tag_name"`
 }
 
+// The tags to attach to and detach from an article along with its update
+type ArticleTagsDiff struct {
+	Added   []InsertArticleTagsArgs
+	Removed []string
+}
+
 func (r *ArticleTagsRepo) InsertBulk(ctx context.Context, tags []InsertArticleTagsArgs) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -28,15 +35,48 @@ func (r *ArticleTagsRepo) InsertBulk(ctx context.Context, tags []InsertArticleTa
 	}
 	defer tx.Rollback()
 
-	query := `
-	INSERT INTO article_tags (article_id, tag_name)
-	VALUES (:article_id, :tag_name)`
-	if _, err = tx.NamedExecContext(ctx, query, tags); err != nil {
+	if err = insertArticleTags(ctx, tx, tags); err != nil {
 		return err
 	}
 	return tx.Commit()
 }
 
+func insertArticleTags(ctx context.Context, tx *sqlx.Tx, tags []InsertArticleTagsArgs) error {
+	// Make sure every tag exists and bump its usage count
+	// before the article references it.
+	query := `
+	INSERT INTO tags (name, articles_count)
+	VALUES (:tag_name, 1)
+	ON CONFLICT (name) DO UPDATE
+	SET articles_count = tags.articles_count + 1, updated_at = NOW()`
+	if _, err := tx.NamedExecContext(ctx, query, tags); err != nil {
+		return err
+	}
+
+	query = `
+	INSERT INTO article_tags (article_id, tag_name)
+	VALUES (:article_id, :tag_name)`
+	_, err := tx.NamedExecContext(ctx, query, tags)
+	return err
+}
+
+// Detach tags from an article and decrement the usage count
+// of the tags that were actually attached.
+func deleteArticleTags(ctx context.Context, tx *sqlx.Tx, articleID string, tags []string) error {
+	query := `
+	WITH deleted AS (
+		DELETE FROM article_tags as at
+		WHERE at.article_id = $1 AND at.tag_name = ANY($2)
+		RETURNING at.tag_name
+	)
+	UPDATE tags
+	SET articles_count = tags.articles_count - 1, updated_at = NOW()
+	FROM deleted
+	WHERE tags.name = deleted.tag_name`
+	_, err := tx.ExecContext(ctx, query, articleID, pq.Array(tags))
+	return err
+}
+
 func (r *ArticleTagsRepo) FindArticleTagsByID(ctx context.Context, articleID string) ([]string, error) {
 	var tags []string
 
@@ -52,7 +92,7 @@ func (r *ArticleTagsRepo) FindArticleTagsByID(ctx context.Context, articleID str
 
 func (r *ArticleTagsRepo) FindAllTags(ctx context.Context) ([]string, error) {
 	var tags []string
-	query := "SELECT DISTINCT(tag_name) FROM article_tags ORDER BY tag_name ASC"
+	query := "SELECT t.name FROM tags as t WHERE t.articles_count > 0 ORDER BY t.name ASC"
 	if err := r.db.SelectContext(ctx, &tags, query); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type BlockingRepoImpl struct {
+	db *sqlx.DB
+}
+
+type BlockingRepository interface {
+	InsertOne(context.Context, string, string) error
+	DeleteOne(context.Context, string, string) error
+	FindOneByIDs(context.Context, string, string) (*string, error)
+	IsBlockedEither(context.Context, string, string) (bool, error)
+}
+
+// Blocking also removes the follow relation and pending follow requests in both directions
+func (r *BlockingRepoImpl) InsertOne(ctx context.Context, blocker, blocked string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO user_blocks (blocker_username, blocked_username) VALUES ($1, $2)"
+	if _, err = tx.ExecContext(ctx, query, blocker, blocked); err != nil {
+		return err
+	}
+
+	query = `
+	DELETE FROM followings as f
+	WHERE (f.follower_username = $1 AND f.following_username = $2)
+	OR (f.follower_username = $2 AND f.following_username = $1)`
+	if _, err = tx.ExecContext(ctx, query, blocker, blocked); err != nil {
+		return err
+	}
+
+	query = `
+	DELETE FROM follow_requests as fr
+	WHERE (fr.requester_username = $1 AND fr.target_username = $2)
+	OR (fr.requester_username = $2 AND fr.target_username = $1)`
+	if _, err = tx.ExecContext(ctx, query, blocker, blocked); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *BlockingRepoImpl) DeleteOne(ctx context.Context, blocker, blocked string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM user_blocks as ub WHERE ub.blocker_username = $1 AND ub.blocked_username = $2"
+	if _, err = tx.ExecContext(ctx, query, blocker, blocked); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Returns pointer to the blocked username.
+// To determine if "blocker" is blocking "blocked".
+// Check if pointer is not nill and err is nil
+func (r *BlockingRepoImpl) FindOneByIDs(ctx context.Context, blocker, blocked string) (*string, error) {
+	var ptr string
+	query := `
+	SELECT ub.blocked_username FROM user_blocks as ub
+	WHERE ub.blocker_username = $1 AND ub.blocked_username = $2`
+	if err := r.db.QueryRowContext(ctx, query, blocker, blocked).Scan(&ptr); err != nil {
+		return nil, err
+	}
+	return &ptr, nil
+}
+
+// Check whether any of the two users is blocking the other
+func (r *BlockingRepoImpl) IsBlockedEither(ctx context.Context, a, b string) (bool, error) {
+	var blocked bool
+	query := `
+	SELECT EXISTS (
+		SELECT 1 FROM user_blocks as ub
+		WHERE (ub.blocker_username = $1 AND ub.blocked_username = $2)
+		OR (ub.blocker_username = $2 AND ub.blocked_username = $1)
+	)`
+	if err := r.db.QueryRowContext(ctx, query, a, b).Scan(&blocked); err != nil {
+		return false, err
+	}
+	return blocked, nil
+}
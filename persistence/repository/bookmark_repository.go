@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type BookmarkRepoImpl struct {
+	db *sqlx.DB
+}
+
+type BookmarkRepository interface {
+	InsertOne(context.Context, string, string, string) error
+	DeleteOne(context.Context, string, string) error
+	FindCollections(context.Context, string) ([]*model.BookmarkCollection, error)
+	DeleteCollection(context.Context, string, string) error
+	FindArticleIDsByUsername(context.Context, string) ([]string, error)
+}
+
+// Bookmark an article, or move an existing bookmark to another collection.
+// The collection is created on the fly, an empty name leaves the bookmark uncategorized.
+func (r *BookmarkRepoImpl) InsertOne(ctx context.Context, username, articleID, collection string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var collectionID *string
+	if collection != "" {
+		query := `
+		INSERT INTO bookmark_collections (username, name) VALUES ($1, $2)
+		ON CONFLICT (username, name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id`
+		if err = tx.QueryRowContext(ctx, query, username, collection).Scan(&collectionID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+	INSERT INTO bookmarks (username, article_id, collection_id) VALUES ($1, $2, $3)
+	ON CONFLICT (username, article_id) DO UPDATE SET collection_id = EXCLUDED.collection_id`
+	if _, err = tx.ExecContext(ctx, query, username, articleID, collectionID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *BookmarkRepoImpl) DeleteOne(ctx context.Context, username, articleID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM bookmarks as b WHERE b.username = $1 AND b.article_id = $2"
+	if _, err = tx.ExecContext(ctx, query, username, articleID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *BookmarkRepoImpl) FindCollections(ctx context.Context, username string) ([]*model.BookmarkCollection, error) {
+	collections := []*model.BookmarkCollection{}
+	query := `
+	SELECT
+		bc.id, bc.name, bc.created_at,
+		(
+			SELECT COUNT(*) FROM bookmarks as b
+			WHERE b.collection_id = bc.id
+		) as bookmarks_count
+	FROM bookmark_collections as bc
+	WHERE bc.username = $1
+	ORDER BY bc.name ASC`
+	if err := r.db.SelectContext(ctx, &collections, query, username); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// Bookmarks of a removed collection are kept uncategorized
+func (r *BookmarkRepoImpl) DeleteCollection(ctx context.Context, username, name string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM bookmark_collections as bc WHERE bc.username = $1 AND bc.name = $2"
+	if _, err = tx.ExecContext(ctx, query, username, name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Every article "username" has bookmarked, in any collection
+func (r *BookmarkRepoImpl) FindArticleIDsByUsername(ctx context.Context, username string) ([]string, error) {
+	ids := []string{}
+	query := "SELECT b.article_id FROM bookmarks as b WHERE b.username = $1"
+	if err := r.db.SelectContext(ctx, &ids, query, username); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/ashalfarhan/realworld/model"
 	"github.com/jmoiron/sqlx"
@@ -13,9 +14,12 @@ type CommentRepoImpl struct {
 
 type CommentRepository interface {
 	InsertOne(context.Context, *model.Comment) error
-	FindByArticleID(context.Context, string) ([]*model.Comment, error)
+	FindByArticleID(context.Context, *model.FindCommentsArgs) ([]*model.Comment, error)
+	CountByArticleID(context.Context, *model.FindCommentsArgs) (int, error)
 	DeleteByID(context.Context, string) error
 	FindOneByID(context.Context, string) (*model.Comment, error)
+	UpdateOne(context.Context, *model.Comment) error
+	FindEditsByID(context.Context, string) ([]*model.CommentEdit, error)
 }
 
 func (r *CommentRepoImpl) InsertOne(ctx context.Context, c *model.Comment) error {
@@ -26,8 +30,8 @@ func (r *CommentRepoImpl) InsertOne(ctx context.Context, c *model.Comment) error
 	defer tx.Rollback()
 
 	query := `
-	INSERT INTO article_comments (body, author_username, article_id)
-	VALUES (:body, :author_username, :article_id)
+	INSERT INTO article_comments (body, author_username, article_id, parent_id)
+	VALUES (:body, :author_username, :article_id, :parent_id)
 	RETURNING id, created_at, updated_at`
 	stmt, err := tx.PrepareNamedContext(ctx, query)
 	if err != nil {
@@ -41,43 +45,215 @@ func (r *CommentRepoImpl) InsertOne(ctx context.Context, c *model.Comment) error
 	return tx.Commit()
 }
 
-func (r *CommentRepoImpl) FindByArticleID(ctx context.Context, articleID string) ([]*model.Comment, error) {
+// Authors the viewer has muted, or is blocking or blocked by
+const hiddenCommentAuthors = `
+	hidden AS (
+		SELECT ub.blocked_username as username FROM user_blocks as ub WHERE ub.blocker_username = :username
+		UNION
+		SELECT ub.blocker_username FROM user_blocks as ub WHERE ub.blocked_username = :username
+		UNION
+		SELECT um.muted_username FROM user_mutes as um WHERE um.muter_username = :username
+	)`
+
+// Walk a page of comment threads of an article down to args.Depth levels.
+// Comments from hidden authors are left out together with their replies.
+// Rows are ordered by depth so parents always come before their replies,
+// top level comments are newest-first and replies oldest-first.
+func (r *CommentRepoImpl) FindByArticleID(ctx context.Context, args *model.FindCommentsArgs) ([]*model.Comment, error) {
 	var comments []*model.Comment
 	query := `
+	WITH RECURSIVE` + hiddenCommentAuthors + `, roots AS (
+		SELECT
+			ac.id, ac.body, ac.parent_id, ac.author_username,
+			ac.deleted_at, ac.created_at, ac.updated_at
+		FROM article_comments as ac
+		WHERE ac.article_id = :article_id
+		AND ac.parent_id IS NULL
+		AND ac.author_username NOT IN (SELECT username FROM hidden)
+		AND (NOT :has_cursor OR (ac.created_at, CAST(ac.id AS TEXT)) < (:cursor_time, :cursor_id))
+		ORDER BY ac.created_at DESC, CAST(ac.id AS TEXT) DESC
+		LIMIT :limit
+	), thread AS (
+		SELECT
+			rt.id, rt.body, rt.parent_id, rt.author_username,
+			rt.deleted_at, rt.created_at, rt.updated_at, 1 as depth
+		FROM roots as rt
+		UNION ALL
+		SELECT
+			ac.id, ac.body, ac.parent_id, ac.author_username,
+			ac.deleted_at, ac.created_at, ac.updated_at, t.depth + 1
+		FROM article_comments as ac
+		JOIN thread as t
+			ON ac.parent_id = t.id
+		WHERE t.depth < :depth
+		AND ac.author_username NOT IN (SELECT username FROM hidden)
+	)
 	SELECT 
-		ac.id, ac.body, ac.created_at, ac.updated_at,
-		us.username as "author.username", us.bio AS "author.bio", us.image AS "author.image"
-	FROM article_comments AS ac 
+		t.id, t.body, t.parent_id, t.deleted_at, t.depth, t.created_at, t.updated_at,
+		(
+			SELECT COUNT(*) FROM article_comments as rc
+			WHERE rc.parent_id = t.id
+			AND rc.author_username NOT IN (SELECT username FROM hidden)
+		) as replies_count,
+		EXISTS (
+			SELECT 1 FROM article_comment_edits as ace WHERE ace.comment_id = t.id
+		) as edited,
+		ARRAY(
+			SELECT m.username FROM mentions as m
+			WHERE m.comment_id = t.id ORDER BY m.username
+		) as mentions,
+		us.username as "author.username", us.bio AS "author.bio", us.image AS "author.image",
+		EXISTS (
+			SELECT 1 FROM followings as f
+			WHERE f.follower_username = :username
+			AND f.following_username = us.username
+		) as "author.following"
+	FROM thread AS t
 	LEFT JOIN users AS us 
-		ON us.username = ac.author_username
-	WHERE ac.article_id = $1
-	ORDER BY created_at DESC`
-	if err := r.db.SelectContext(ctx, &comments, query, articleID); err != nil {
+		ON us.username = t.author_username
+	ORDER BY
+		t.depth ASC,
+		CASE WHEN t.depth = 1 THEN t.created_at END DESC,
+		CASE WHEN t.depth = 1 THEN CAST(t.id AS TEXT) END DESC,
+		t.created_at ASC`
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.SelectContext(ctx, &comments, args); err != nil {
 		return nil, err
 	}
 	return comments, nil
 }
 
+// Count the top level comments of an article visible to args.Username
+func (r *CommentRepoImpl) CountByArticleID(ctx context.Context, args *model.FindCommentsArgs) (int, error) {
+	var count int
+	query := `
+	WITH` + hiddenCommentAuthors + `
+	SELECT COUNT(*) FROM article_comments as ac
+	WHERE ac.article_id = :article_id
+	AND ac.parent_id IS NULL
+	AND ac.author_username NOT IN (SELECT username FROM hidden)`
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.GetContext(ctx, &count, args); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// A comment that still has replies is only marked as deleted
+// so the thread stays intact, otherwise it is removed for good.
+// Removing the last reply of a deleted comment also removes that comment,
+// all the way up the thread.
 func (r *CommentRepoImpl) DeleteByID(ctx context.Context, id string) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
-	query := "DELETE FROM article_comments as ac WHERE ac.id = $1"
-	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+
+	var hasReplies bool
+	query := "SELECT EXISTS (SELECT 1 FROM article_comments as ac WHERE ac.parent_id = $1)"
+	if err = tx.QueryRowContext(ctx, query, id).Scan(&hasReplies); err != nil {
+		return err
+	}
+
+	if hasReplies {
+		query = "UPDATE article_comments SET deleted_at = NOW() WHERE id = $1"
+		if _, err = tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	var parentID *string
+	query = "DELETE FROM article_comments as ac WHERE ac.id = $1 RETURNING ac.parent_id"
+	if err = tx.QueryRowContext(ctx, query, id).Scan(&parentID); err != nil {
 		return err
 	}
+
+	query = `
+	DELETE FROM article_comments as ac
+	WHERE ac.id = $1
+	AND ac.deleted_at IS NOT NULL
+	AND NOT EXISTS (SELECT 1 FROM article_comments as rc WHERE rc.parent_id = ac.id)
+	RETURNING ac.parent_id`
+	for parentID != nil {
+		var next *string
+		if err = tx.QueryRowContext(ctx, query, *parentID).Scan(&next); err != nil {
+			if err == sql.ErrNoRows {
+				break
+			}
+			return err
+		}
+		parentID = next
+	}
 	return tx.Commit()
 }
 
 func (r *CommentRepoImpl) FindOneByID(ctx context.Context, id string) (*model.Comment, error) {
 	comm := &model.Comment{}
 	query := `
-	SELECT id, body, author_username, created_at, updated_at
+	SELECT
+		ac.id, ac.body, ac.article_id, ac.parent_id, ac.author_username,
+		ac.deleted_at, ac.created_at, ac.updated_at,
+		EXISTS (
+			SELECT 1 FROM article_comment_edits as ace WHERE ace.comment_id = ac.id
+		) as edited,
+		ARRAY(
+			SELECT m.username FROM mentions as m
+			WHERE m.comment_id = ac.id ORDER BY m.username
+		) as mentions
 	FROM article_comments as ac WHERE ac.id = $1`
 	if err := r.db.GetContext(ctx, comm, query, id); err != nil {
 		return nil, err
 	}
 	return comm, nil
 }
+
+// Keep the current body in the edit history before replacing it
+func (r *CommentRepoImpl) UpdateOne(ctx context.Context, c *model.Comment) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO article_comment_edits (comment_id, body)
+	SELECT ac.id, ac.body FROM article_comments as ac WHERE ac.id = $1`
+	if _, err = tx.ExecContext(ctx, query, c.ID); err != nil {
+		return err
+	}
+
+	query = `
+	UPDATE article_comments
+	SET body = $2, updated_at = NOW()
+	WHERE id = $1
+	RETURNING updated_at`
+	if err = tx.QueryRowContext(ctx, query, c.ID, c.Body).Scan(&c.UpdatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Prior versions of a comment, newest-first
+func (r *CommentRepoImpl) FindEditsByID(ctx context.Context, id string) ([]*model.CommentEdit, error) {
+	edits := []*model.CommentEdit{}
+	query := `
+	SELECT ace.body, ace.edited_at FROM article_comment_edits as ace
+	WHERE ace.comment_id = $1
+	ORDER BY ace.edited_at DESC, ace.id DESC`
+	if err := r.db.SelectContext(ctx, &edits, query, id); err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
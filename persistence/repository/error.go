@@ -1,7 +1,18 @@
 package repository
 
+import "errors"
+
+// The row was updated since it was read, its version moved on
+var ErrStaleVersion = errors.New("stale version")
+
 const (
-	ErrDuplicateEmail     = "pq: duplicate key value violates unique constraint \"users_email_key\""
-	ErrDuplicateUsername  = "pq: duplicate key value violates unique constraint \"users_username_key\""
-	ErrDuplicateFollowing = "pq: duplicate key value violates unique constraint \"followings_pkey\""
+	ErrDuplicateEmail           = "pq: duplicate key value violates unique constraint \"users_email_key\""
+	ErrDuplicateUsername        = "pq: duplicate key value violates unique constraint \"users_username_key\""
+	ErrDuplicateFollowing       = "pq: duplicate key value violates unique constraint \"followings_pkey\""
+	ErrDuplicateTagFollow       = "pq: duplicate key value violates unique constraint \"tag_followings_pkey\""
+	ErrDuplicateBlocking        = "pq: duplicate key value violates unique constraint \"user_blocks_pkey\""
+	ErrDuplicateMuting          = "pq: duplicate key value violates unique constraint \"user_mutes_pkey\""
+	ErrDuplicateRequest         = "pq: duplicate key value violates unique constraint \"follow_requests_pkey\""
+	ErrDuplicateArticleReaction = "pq: duplicate key value violates unique constraint \"reactions_article_key\""
+	ErrDuplicateCommentReaction = "pq: duplicate key value violates unique constraint \"reactions_comment_key\""
 )
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type FollowRequestRepoImpl struct {
+	db *sqlx.DB
+}
+
+type FollowRequestRepository interface {
+	InsertOne(context.Context, string, string) error
+	DeleteOne(context.Context, string, string) error
+	FindOneByIDs(context.Context, string, string) (*string, error)
+	Approve(context.Context, string, string) error
+	FindIncoming(context.Context, *model.FindFollowsArgs) ([]*model.ProfileRs, error)
+	FindOutgoing(context.Context, *model.FindFollowsArgs) ([]*model.ProfileRs, error)
+	CountByUsername(context.Context, string) (int, int, error)
+}
+
+func (r *FollowRequestRepoImpl) InsertOne(ctx context.Context, requester, target string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO follow_requests (requester_username, target_username) VALUES ($1, $2)"
+	if _, err = tx.ExecContext(ctx, query, requester, target); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *FollowRequestRepoImpl) DeleteOne(ctx context.Context, requester, target string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM follow_requests as fr WHERE fr.requester_username = $1 AND fr.target_username = $2"
+	if _, err = tx.ExecContext(ctx, query, requester, target); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Returns pointer to the target username.
+// To determine if "requester" has a pending request to follow "target".
+// Check if pointer is not nill and err is nil
+func (r *FollowRequestRepoImpl) FindOneByIDs(ctx context.Context, requester, target string) (*string, error) {
+	var ptr string
+	query := `
+	SELECT fr.target_username FROM follow_requests as fr
+	WHERE fr.requester_username = $1 AND fr.target_username = $2`
+	if err := r.db.QueryRowContext(ctx, query, requester, target).Scan(&ptr); err != nil {
+		return nil, err
+	}
+	return &ptr, nil
+}
+
+// Turn a pending request into a following.
+// Returns sql.ErrNoRows if there is no such request.
+func (r *FollowRequestRepoImpl) Approve(ctx context.Context, requester, target string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	DELETE FROM follow_requests as fr
+	WHERE fr.requester_username = $1 AND fr.target_username = $2`
+	res, err := tx.ExecContext(ctx, query, requester, target)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	query = `
+	INSERT INTO followings (follower_username, following_username) VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`
+	if _, err = tx.ExecContext(ctx, query, requester, target); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Approve every pending request to follow "target",
+// used when a private profile is made public.
+func approveAllFollowRequests(ctx context.Context, tx *sqlx.Tx, target string) error {
+	query := `
+	INSERT INTO followings (follower_username, following_username)
+	SELECT fr.requester_username, fr.target_username
+	FROM follow_requests as fr
+	WHERE fr.target_username = $1
+	ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, target); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM follow_requests as fr WHERE fr.target_username = $1"
+	_, err := tx.ExecContext(ctx, query, target)
+	return err
+}
+
+// Find the users requesting to follow args.Username
+func (r *FollowRequestRepoImpl) FindIncoming(ctx context.Context, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	query := `
+	SELECT
+		us.username, us.bio, us.image,
+		EXISTS (
+			SELECT 1 FROM followings as vf
+			WHERE vf.follower_username = :viewer
+			AND vf.following_username = us.username
+		) as "following"
+	FROM follow_requests as fr
+	JOIN users as us
+		ON us.username = fr.requester_username
+	WHERE fr.target_username = :username
+	ORDER BY fr.created_at DESC, us.username ASC
+	LIMIT :limit OFFSET :offset`
+	return r.findProfiles(ctx, query, p)
+}
+
+// Find the users args.Username is requesting to follow
+func (r *FollowRequestRepoImpl) FindOutgoing(ctx context.Context, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	query := `
+	SELECT
+		us.username, us.bio, us.image,
+		FALSE as "following",
+		TRUE as "followrequested"
+	FROM follow_requests as fr
+	JOIN users as us
+		ON us.username = fr.target_username
+	WHERE fr.requester_username = :username
+	ORDER BY fr.created_at DESC, us.username ASC
+	LIMIT :limit OFFSET :offset`
+	return r.findProfiles(ctx, query, p)
+}
+
+// Returns the incoming and outgoing request count of "username"
+func (r *FollowRequestRepoImpl) CountByUsername(ctx context.Context, username string) (int, int, error) {
+	var incoming, outgoing int
+	query := `
+	SELECT
+		COUNT(*) FILTER (WHERE fr.target_username = $1),
+		COUNT(*) FILTER (WHERE fr.requester_username = $1)
+	FROM follow_requests as fr
+	WHERE fr.target_username = $1 OR fr.requester_username = $1`
+	if err := r.db.QueryRowContext(ctx, query, username).Scan(&incoming, &outgoing); err != nil {
+		return 0, 0, err
+	}
+	return incoming, outgoing, nil
+}
+
+func (r *FollowRequestRepoImpl) findProfiles(ctx context.Context, query string, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	profiles := []*model.ProfileRs{}
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.SelectContext(ctx, &profiles, p); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
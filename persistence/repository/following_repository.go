@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/ashalfarhan/realworld/model"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -14,6 +15,11 @@ type FollowingRepository interface {
 	InsertOne(context.Context, string, string) error
 	DeleteOneIDs(context.Context, string, string) error
 	FindOneByIDs(context.Context, string, string) (*string, error)
+	FindFollowers(context.Context, *model.FindFollowsArgs) ([]*model.ProfileRs, error)
+	FindFollowings(context.Context, *model.FindFollowsArgs) ([]*model.ProfileRs, error)
+	CountByUsername(context.Context, string) (int, int, error)
+	FindFollowingUsernames(context.Context, string) ([]string, error)
+	FindAllFollowingUsernames(context.Context, string) ([]string, error)
 }
 
 func (r *FollowingRepoImpl) InsertOne(ctx context.Context, follower, following string) error {
@@ -58,3 +64,97 @@ func (r *FollowingRepoImpl) FindOneByIDs(ctx context.Context, follower, followin
 
 	return &ptr, nil
 }
+
+// Find the users following "username",
+// "following" is computed for the viewer on each of them.
+func (r *FollowingRepoImpl) FindFollowers(ctx context.Context, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	query := `
+	SELECT
+		us.username, us.bio, us.image,
+		EXISTS (
+			SELECT 1 FROM followings as vf
+			WHERE vf.follower_username = :viewer
+			AND vf.following_username = us.username
+		) as "following"
+	FROM followings as f
+	JOIN users as us
+		ON us.username = f.follower_username
+	WHERE f.following_username = :username
+	ORDER BY f.created_at DESC, us.username ASC
+	LIMIT :limit OFFSET :offset`
+	return r.findProfiles(ctx, query, p)
+}
+
+// Find the users "username" is following,
+// "following" is computed for the viewer on each of them.
+func (r *FollowingRepoImpl) FindFollowings(ctx context.Context, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	query := `
+	SELECT
+		us.username, us.bio, us.image,
+		EXISTS (
+			SELECT 1 FROM followings as vf
+			WHERE vf.follower_username = :viewer
+			AND vf.following_username = us.username
+		) as "following"
+	FROM followings as f
+	JOIN users as us
+		ON us.username = f.following_username
+	WHERE f.follower_username = :username
+	ORDER BY f.created_at DESC, us.username ASC
+	LIMIT :limit OFFSET :offset`
+	return r.findProfiles(ctx, query, p)
+}
+
+func (r *FollowingRepoImpl) findProfiles(ctx context.Context, query string, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	profiles := []*model.ProfileRs{}
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.SelectContext(ctx, &profiles, p); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Returns the followers and following count of "username"
+func (r *FollowingRepoImpl) CountByUsername(ctx context.Context, username string) (int, int, error) {
+	var followers, following int
+	query := `
+	SELECT
+		COUNT(*) FILTER (WHERE f.following_username = $1),
+		COUNT(*) FILTER (WHERE f.follower_username = $1)
+	FROM followings as f
+	WHERE f.following_username = $1 OR f.follower_username = $1`
+	if err := r.db.QueryRowContext(ctx, query, username).Scan(&followers, &following); err != nil {
+		return 0, 0, err
+	}
+	return followers, following, nil
+}
+
+// Every author "username" follows and has not muted
+func (r *FollowingRepoImpl) FindFollowingUsernames(ctx context.Context, username string) ([]string, error) {
+	usernames := []string{}
+	query := `
+	SELECT f.following_username FROM followings as f
+	WHERE f.follower_username = $1
+	AND f.following_username NOT IN (
+		SELECT um.muted_username FROM user_mutes as um WHERE um.muter_username = $1
+	)`
+	if err := r.db.SelectContext(ctx, &usernames, query, username); err != nil {
+		return nil, err
+	}
+	return usernames, nil
+}
+
+// Unlike FindFollowingUsernames this includes the muted users
+func (r *FollowingRepoImpl) FindAllFollowingUsernames(ctx context.Context, username string) ([]string, error) {
+	usernames := []string{}
+	query := "SELECT f.following_username FROM followings as f WHERE f.follower_username = $1"
+	if err := r.db.SelectContext(ctx, &usernames, query, username); err != nil {
+		return nil, err
+	}
+	return usernames, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type MentionRepoImpl struct {
+	db *sqlx.DB
+}
+
+type MentionRepository interface {
+	ReplaceArticleMentions(context.Context, string, []string) ([]string, error)
+	ReplaceCommentMentions(context.Context, string, string, []string) ([]string, error)
+}
+
+// Make "usernames" the mentions of the article body.
+// Returns the usernames that were not mentioned before.
+func (r *MentionRepoImpl) ReplaceArticleMentions(ctx context.Context, articleID string, usernames []string) ([]string, error) {
+	return r.replace(ctx, articleID, nil, usernames)
+}
+
+// Make "usernames" the mentions of the comment body.
+// Returns the usernames that were not mentioned before.
+func (r *MentionRepoImpl) ReplaceCommentMentions(ctx context.Context, articleID, commentID string, usernames []string) ([]string, error) {
+	return r.replace(ctx, articleID, &commentID, usernames)
+}
+
+func (r *MentionRepoImpl) replace(ctx context.Context, articleID string, commentID *string, usernames []string) ([]string, error) {
+	added := []string{}
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	DELETE FROM mentions as m
+	WHERE m.article_id = $1
+	AND m.comment_id IS NOT DISTINCT FROM CAST($2 AS UUID)
+	AND NOT (m.username = ANY($3))`
+	if _, err = tx.ExecContext(ctx, query, articleID, commentID, pq.Array(usernames)); err != nil {
+		return nil, err
+	}
+
+	query = `
+	INSERT INTO mentions (article_id, comment_id, username)
+	SELECT $1, CAST($2 AS UUID), u.username
+	FROM unnest(CAST($3 AS VARCHAR[])) as u(username)
+	ON CONFLICT DO NOTHING
+	RETURNING username`
+	if err = tx.SelectContext(ctx, &added, query, articleID, commentID, pq.Array(usernames)); err != nil {
+		return nil, err
+	}
+	return added, tx.Commit()
+}
@@ -0,0 +1,36 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ArticleFavoritesRepoMock struct {
+	mock.Mock
+}
+
+func (m *ArticleFavoritesRepoMock) InsertOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *ArticleFavoritesRepoMock) Delete(ctx context.Context, s string, sa string) (int64, error) {
+	args := m.Called(ctx, s, sa)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ArticleFavoritesRepoMock) FindOneByIDs(ctx context.Context, s string, sa string) (*string, error) {
+	args := m.Called(ctx, s, sa)
+	return args.Get(0).(*string), args.Error(1)
+}
+
+func (m *ArticleFavoritesRepoMock) CountFavorites(ctx context.Context, s string) (int, error) {
+	args := m.Called(ctx, s)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ArticleFavoritesRepoMock) FindArticleIDsByUsername(ctx context.Context, s string) ([]string, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -18,7 +19,7 @@ func (m *ArticleRepoMock) InsertOne(ctx context.Context, a *model.CreateArticleF
 
 func (m *ArticleRepoMock) FindOneBySlug(ctx context.Context, u, s string) (*model.Article, error) {
 	args := m.Called(ctx, u, s)
-	return args.Get(1).(*model.Article), args.Error(1)
+	return args.Get(0).(*model.Article), args.Error(1)
 }
 
 func (m *ArticleRepoMock) DeleteBySlug(ctx context.Context, s string) error {
@@ -26,8 +27,8 @@ func (m *ArticleRepoMock) DeleteBySlug(ctx context.Context, s string) error {
 	return args.Error(0)
 }
 
-func (m *ArticleRepoMock) UpdateOneBySlug(ctx context.Context, d *model.UpdateArticleFields, a *model.Article) error {
-	args := m.Called(ctx, d, a)
+func (m *ArticleRepoMock) UpdateOneBySlug(ctx context.Context, d *model.UpdateArticleFields, a *model.Article, tags *repository.ArticleTagsDiff) error {
+	args := m.Called(ctx, d, a, tags)
 	return args.Error(0)
 }
 
@@ -35,3 +36,8 @@ func (m *ArticleRepoMock) Find(ctx context.Context, a *model.FindArticlesArgs) (
 	args := m.Called(ctx, a)
 	return args.Get(0).(model.Articles), args.Error(1)
 }
+
+func (m *ArticleRepoMock) FindSlugsByAuthor(ctx context.Context, username string) ([]string, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,31 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BlockingRepoMock struct {
+	mock.Mock
+}
+
+func (m *BlockingRepoMock) InsertOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *BlockingRepoMock) DeleteOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *BlockingRepoMock) FindOneByIDs(ctx context.Context, s string, sa string) (*string, error) {
+	args := m.Called(ctx, s, sa)
+	return args.Get(0).(*string), args.Error(1)
+}
+
+func (m *BlockingRepoMock) IsBlockedEither(ctx context.Context, s string, sa string) (bool, error) {
+	args := m.Called(ctx, s, sa)
+	return args.Bool(0), args.Error(1)
+}
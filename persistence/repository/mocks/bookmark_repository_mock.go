@@ -0,0 +1,37 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type BookmarkRepoMock struct {
+	mock.Mock
+}
+
+func (m *BookmarkRepoMock) InsertOne(ctx context.Context, s string, sa string, sb string) error {
+	args := m.Called(ctx, s, sa, sb)
+	return args.Error(0)
+}
+
+func (m *BookmarkRepoMock) DeleteOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *BookmarkRepoMock) FindCollections(ctx context.Context, s string) ([]*model.BookmarkCollection, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).([]*model.BookmarkCollection), args.Error(1)
+}
+
+func (m *BookmarkRepoMock) DeleteCollection(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *BookmarkRepoMock) FindArticleIDsByUsername(ctx context.Context, s string) ([]string, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).([]string), args.Error(1)
+}
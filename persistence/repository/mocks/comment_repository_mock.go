@@ -16,8 +16,8 @@ func (m *CommentRepoMock) InsertOne(ctx context.Context, c *model.Comment) error
 	return args.Error(0)
 }
 
-func (m *CommentRepoMock) FindByArticleID(ctx context.Context, articleID string) ([]*model.Comment, error) {
-	args := m.Called(ctx, articleID)
+func (m *CommentRepoMock) FindByArticleID(ctx context.Context, a *model.FindCommentsArgs) ([]*model.Comment, error) {
+	args := m.Called(ctx, a)
 	return args.Get(0).([]*model.Comment), args.Error(1)
 }
 
@@ -27,6 +27,21 @@ func (m *CommentRepoMock) DeleteByID(ctx context.Context, commentID string) erro
 }
 
 func (m *CommentRepoMock) FindOneByID(ctx context.Context, commentID string) (*model.Comment, error) {
-	args := m.Called(ctx)
+	args := m.Called(ctx, commentID)
 	return args.Get(0).(*model.Comment), args.Error(1)
 }
+
+func (m *CommentRepoMock) UpdateOne(ctx context.Context, c *model.Comment) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *CommentRepoMock) FindEditsByID(ctx context.Context, id string) ([]*model.CommentEdit, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*model.CommentEdit), args.Error(1)
+}
+
+func (m *CommentRepoMock) CountByArticleID(ctx context.Context, a *model.FindCommentsArgs) (int, error) {
+	args := m.Called(ctx, a)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,47 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type FollowRequestRepoMock struct {
+	mock.Mock
+}
+
+func (m *FollowRequestRepoMock) InsertOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *FollowRequestRepoMock) DeleteOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *FollowRequestRepoMock) FindOneByIDs(ctx context.Context, s string, sa string) (*string, error) {
+	args := m.Called(ctx, s, sa)
+	return args.Get(0).(*string), args.Error(1)
+}
+
+func (m *FollowRequestRepoMock) Approve(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *FollowRequestRepoMock) FindIncoming(ctx context.Context, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	args := m.Called(ctx, p)
+	return args.Get(0).([]*model.ProfileRs), args.Error(1)
+}
+
+func (m *FollowRequestRepoMock) FindOutgoing(ctx context.Context, p *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	args := m.Called(ctx, p)
+	return args.Get(0).([]*model.ProfileRs), args.Error(1)
+}
+
+func (m *FollowRequestRepoMock) CountByUsername(ctx context.Context, s string) (int, int, error) {
+	args := m.Called(ctx, s)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
@@ -3,6 +3,7 @@ package repository_mocks
 import (
 	"context"
 
+	"github.com/ashalfarhan/realworld/model"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -24,3 +25,28 @@ func (m *FollowingRepoMock) FindOneByIDs(ctx context.Context, s string, sa strin
 	args := m.Called(ctx, s, sa)
 	return args.Get(0).(*string), args.Error(1)
 }
+
+func (m *FollowingRepoMock) FindFollowers(ctx context.Context, a *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	args := m.Called(ctx, a)
+	return args.Get(0).([]*model.ProfileRs), args.Error(1)
+}
+
+func (m *FollowingRepoMock) FindFollowings(ctx context.Context, a *model.FindFollowsArgs) ([]*model.ProfileRs, error) {
+	args := m.Called(ctx, a)
+	return args.Get(0).([]*model.ProfileRs), args.Error(1)
+}
+
+func (m *FollowingRepoMock) CountByUsername(ctx context.Context, s string) (int, int, error) {
+	args := m.Called(ctx, s)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *FollowingRepoMock) FindFollowingUsernames(ctx context.Context, s string) ([]string, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *FollowingRepoMock) FindAllFollowingUsernames(ctx context.Context, s string) ([]string, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MentionRepoMock struct {
+	mock.Mock
+}
+
+func (m *MentionRepoMock) ReplaceArticleMentions(ctx context.Context, articleID string, usernames []string) ([]string, error) {
+	args := m.Called(ctx, articleID, usernames)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MentionRepoMock) ReplaceCommentMentions(ctx context.Context, articleID, commentID string, usernames []string) ([]string, error) {
+	args := m.Called(ctx, articleID, commentID, usernames)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MutingRepoMock struct {
+	mock.Mock
+}
+
+func (m *MutingRepoMock) InsertOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *MutingRepoMock) DeleteOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *MutingRepoMock) FindOneByIDs(ctx context.Context, s string, sa string) (*string, error) {
+	args := m.Called(ctx, s, sa)
+	return args.Get(0).(*string), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type NotificationRepoMock struct {
+	mock.Mock
+}
+
+func (m *NotificationRepoMock) InsertOne(ctx context.Context, n *model.CreateNotificationArgs) (bool, error) {
+	args := m.Called(ctx, n)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *NotificationRepoMock) Find(ctx context.Context, a *model.FindNotificationsArgs) ([]*model.Notification, error) {
+	args := m.Called(ctx, a)
+	return args.Get(0).([]*model.Notification), args.Error(1)
+}
+
+func (m *NotificationRepoMock) CountUnread(ctx context.Context, s string) (int, error) {
+	args := m.Called(ctx, s)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *NotificationRepoMock) MarkRead(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *NotificationRepoMock) MarkAllRead(ctx context.Context, s string) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
@@ -0,0 +1,37 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReactionRepoMock struct {
+	mock.Mock
+}
+
+func (m *ReactionRepoMock) InsertOne(ctx context.Context, r *model.Reaction) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *ReactionRepoMock) DeleteOne(ctx context.Context, r *model.Reaction) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *ReactionRepoMock) FindByArticleIDs(ctx context.Context, ids []string, username string) ([]*model.ReactionCount, error) {
+	args := m.Called(ctx, ids, username)
+	return args.Get(0).([]*model.ReactionCount), args.Error(1)
+}
+
+func (m *ReactionRepoMock) FindByCommentIDs(ctx context.Context, ids []string, username string) ([]*model.ReactionCount, error) {
+	args := m.Called(ctx, ids, username)
+	return args.Get(0).([]*model.ReactionCount), args.Error(1)
+}
+
+func (m *ReactionRepoMock) FindArticleReactionsByUsername(ctx context.Context, username string) ([]*model.Reaction, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).([]*model.Reaction), args.Error(1)
+}
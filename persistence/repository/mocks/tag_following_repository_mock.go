@@ -0,0 +1,26 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TagFollowingRepoMock struct {
+	mock.Mock
+}
+
+func (m *TagFollowingRepoMock) InsertOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *TagFollowingRepoMock) DeleteOne(ctx context.Context, s string, sa string) error {
+	args := m.Called(ctx, s, sa)
+	return args.Error(0)
+}
+
+func (m *TagFollowingRepoMock) FindTagNames(ctx context.Context, s string) ([]string, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).([]string), args.Error(1)
+}
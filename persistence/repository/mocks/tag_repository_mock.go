@@ -0,0 +1,22 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type TagRepoMock struct {
+	mock.Mock
+}
+
+func (m *TagRepoMock) Find(ctx context.Context, a *model.FindTagsArgs) ([]*model.Tag, error) {
+	args := m.Called(ctx, a)
+	return args.Get(0).([]*model.Tag), args.Error(1)
+}
+
+func (m *TagRepoMock) FindOneByName(ctx context.Context, username, name string) (*model.Tag, error) {
+	args := m.Called(ctx, username, name)
+	return args.Get(0).(*model.Tag), args.Error(1)
+}
@@ -0,0 +1,27 @@
+package repository_mocks
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type UploadRepoMock struct {
+	mock.Mock
+}
+
+func (m *UploadRepoMock) InsertOne(ctx context.Context, u *model.Upload) error {
+	args := m.Called(ctx, u)
+	return args.Error(0)
+}
+
+func (m *UploadRepoMock) FindOneByID(ctx context.Context, id string) (*model.Upload, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.Upload), args.Error(1)
+}
+
+func (m *UploadRepoMock) DeleteOne(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -30,3 +30,8 @@ func (m *UserRepoMock) UpdateOne(ctx context.Context, d *model.UpdateUserFields,
 	arg := m.Called(ctx, d, u)
 	return arg.Error(0)
 }
+
+func (m *UserRepoMock) FindUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	arg := m.Called(ctx, usernames)
+	return arg.Get(0).([]string), arg.Error(1)
+}
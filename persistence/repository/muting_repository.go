@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type MutingRepoImpl struct {
+	db *sqlx.DB
+}
+
+type MutingRepository interface {
+	InsertOne(context.Context, string, string) error
+	DeleteOne(context.Context, string, string) error
+	FindOneByIDs(context.Context, string, string) (*string, error)
+}
+
+func (r *MutingRepoImpl) InsertOne(ctx context.Context, muter, muted string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO user_mutes (muter_username, muted_username) VALUES ($1, $2)"
+	if _, err = tx.ExecContext(ctx, query, muter, muted); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *MutingRepoImpl) DeleteOne(ctx context.Context, muter, muted string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM user_mutes as um WHERE um.muter_username = $1 AND um.muted_username = $2"
+	if _, err = tx.ExecContext(ctx, query, muter, muted); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Returns pointer to the muted username.
+// To determine if "muter" is muting "muted".
+// Check if pointer is not nill and err is nil
+func (r *MutingRepoImpl) FindOneByIDs(ctx context.Context, muter, muted string) (*string, error) {
+	var ptr string
+	query := `
+	SELECT um.muted_username FROM user_mutes as um
+	WHERE um.muter_username = $1 AND um.muted_username = $2`
+	if err := r.db.QueryRowContext(ctx, query, muter, muted).Scan(&ptr); err != nil {
+		return nil, err
+	}
+	return &ptr, nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationRepoImpl struct {
+	db *sqlx.DB
+}
+
+type NotificationRepository interface {
+	InsertOne(context.Context, *model.CreateNotificationArgs) (bool, error)
+	Find(context.Context, *model.FindNotificationsArgs) ([]*model.Notification, error)
+	CountUnread(context.Context, string) (int, error)
+	MarkRead(context.Context, string, string) error
+	MarkAllRead(context.Context, string) error
+}
+
+// Nothing is inserted when the actor is the recipient,
+// or the recipient is blocking or muting the actor.
+// Returns whether the notification was inserted.
+func (r *NotificationRepoImpl) InsertOne(ctx context.Context, n *model.CreateNotificationArgs) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO notifications (recipient_username, actor_username, type, article_id, comment_id)
+	SELECT
+		CAST(:recipient_username AS VARCHAR), CAST(:actor_username AS VARCHAR),
+		CAST(:type AS VARCHAR), CAST(:article_id AS UUID), CAST(:comment_id AS UUID)
+	WHERE CAST(:recipient_username AS VARCHAR) <> CAST(:actor_username AS VARCHAR)
+	AND NOT EXISTS (
+		SELECT 1 FROM user_blocks as ub
+		WHERE ub.blocker_username = :recipient_username
+		AND ub.blocked_username = :actor_username
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM user_mutes as um
+		WHERE um.muter_username = :recipient_username
+		AND um.muted_username = :actor_username
+	)`
+	res, err := tx.NamedExecContext(ctx, query, n)
+	if err != nil {
+		return false, err
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return inserted > 0, tx.Commit()
+}
+
+// Find a page of grouped notifications, latest activity first
+func (r *NotificationRepoImpl) Find(ctx context.Context, p *model.FindNotificationsArgs) ([]*model.Notification, error) {
+	notifications := []*model.Notification{}
+	query := `
+	SELECT
+		(array_agg(n.id ORDER BY n.created_at DESC))[1] as id,
+		n.type, ar.slug as article_slug, ar.title as article_title,
+		array_agg(n.actor_username ORDER BY n.created_at DESC) as actors,
+		COUNT(DISTINCT n.actor_username) as actors_count,
+		n.read_at IS NOT NULL as read,
+		MAX(n.created_at) as created_at
+	FROM notifications as n
+	LEFT JOIN articles as ar
+		ON ar.id = n.article_id
+	WHERE n.recipient_username = :username
+	GROUP BY n.type, n.article_id, ar.slug, ar.title, n.read_at IS NOT NULL
+	ORDER BY MAX(n.created_at) DESC
+	LIMIT :limit OFFSET :offset`
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.SelectContext(ctx, &notifications, p); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// Count the unread notification groups of "username"
+func (r *NotificationRepoImpl) CountUnread(ctx context.Context, username string) (int, error) {
+	var count int
+	query := `
+	SELECT COUNT(DISTINCT (n.type, n.article_id)) FROM notifications as n
+	WHERE n.recipient_username = $1 AND n.read_at IS NULL`
+	if err := r.db.QueryRowContext(ctx, query, username).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Mark the whole group of the given notification as read.
+// Returns sql.ErrNoRows if "username" has no such notification.
+func (r *NotificationRepoImpl) MarkRead(ctx context.Context, username, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var nType string
+	var articleID *string
+	query := `
+	SELECT n.type, n.article_id FROM notifications as n
+	WHERE n.id = $1 AND n.recipient_username = $2`
+	if err = tx.QueryRowContext(ctx, query, id, username).Scan(&nType, &articleID); err != nil {
+		return err
+	}
+
+	query = `
+	UPDATE notifications SET read_at = NOW()
+	WHERE recipient_username = $1
+	AND read_at IS NULL
+	AND type = $2
+	AND article_id IS NOT DISTINCT FROM $3`
+	if _, err = tx.ExecContext(ctx, query, username, nType, articleID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *NotificationRepoImpl) MarkAllRead(ctx context.Context, username string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE notifications SET read_at = NOW() WHERE recipient_username = $1 AND read_at IS NULL"
+	if _, err = tx.ExecContext(ctx, query, username); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
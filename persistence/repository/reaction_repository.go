@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ReactionRepoImpl struct {
+	db *sqlx.DB
+}
+
+type ReactionRepository interface {
+	InsertOne(context.Context, *model.Reaction) error
+	DeleteOne(context.Context, *model.Reaction) error
+	FindByArticleIDs(context.Context, []string, string) ([]*model.ReactionCount, error)
+	FindByCommentIDs(context.Context, []string, string) ([]*model.ReactionCount, error)
+	FindArticleReactionsByUsername(context.Context, string) ([]*model.Reaction, error)
+}
+
+func (r *ReactionRepoImpl) InsertOne(ctx context.Context, re *model.Reaction) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO reactions (username, article_id, comment_id, reaction)
+	VALUES (:username, :article_id, :comment_id, :reaction)`
+	if _, err = tx.NamedExecContext(ctx, query, re); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ReactionRepoImpl) DeleteOne(ctx context.Context, re *model.Reaction) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	DELETE FROM reactions as re
+	WHERE re.username = :username
+	AND re.reaction = :reaction
+	AND (re.article_id = :article_id OR re.comment_id = :comment_id)`
+	if _, err = tx.NamedExecContext(ctx, query, re); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Reaction counts of each article, flagged with whether "username" made them
+func (r *ReactionRepoImpl) FindByArticleIDs(ctx context.Context, ids []string, username string) ([]*model.ReactionCount, error) {
+	query := `
+	SELECT
+		re.article_id as target_id, re.reaction,
+		COUNT(*) as count, BOOL_OR(re.username = $2) as reacted
+	FROM reactions as re
+	WHERE re.article_id = ANY($1)
+	GROUP BY re.article_id, re.reaction
+	ORDER BY re.reaction`
+	return r.findCounts(ctx, query, ids, username)
+}
+
+// Reaction counts of each comment, flagged with whether "username" made them
+func (r *ReactionRepoImpl) FindByCommentIDs(ctx context.Context, ids []string, username string) ([]*model.ReactionCount, error) {
+	query := `
+	SELECT
+		re.comment_id as target_id, re.reaction,
+		COUNT(*) as count, BOOL_OR(re.username = $2) as reacted
+	FROM reactions as re
+	WHERE re.comment_id = ANY($1)
+	GROUP BY re.comment_id, re.reaction
+	ORDER BY re.reaction`
+	return r.findCounts(ctx, query, ids, username)
+}
+
+func (r *ReactionRepoImpl) findCounts(ctx context.Context, query string, ids []string, username string) ([]*model.ReactionCount, error) {
+	counts := []*model.ReactionCount{}
+	if len(ids) == 0 {
+		return counts, nil
+	}
+	if err := r.db.SelectContext(ctx, &counts, query, pq.Array(ids), username); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Every reaction "username" has made on an article
+func (r *ReactionRepoImpl) FindArticleReactionsByUsername(ctx context.Context, username string) ([]*model.Reaction, error) {
+	reactions := []*model.Reaction{}
+	query := `
+	SELECT re.username, re.article_id, re.reaction
+	FROM reactions as re
+	WHERE re.username = $1 AND re.article_id IS NOT NULL`
+	if err := r.db.SelectContext(ctx, &reactions, query, username); err != nil {
+		return nil, err
+	}
+	return reactions, nil
+}
@@ -9,6 +9,16 @@ type Repository struct {
 	ArticleTagsRepo      ArticleTagsRepository
 	ArticleFavoritesRepo ArticleFavoritesRepository
 	CommentRepo          CommentRepository
+	TagRepo              TagRepository
+	TagFollowRepo        TagFollowingRepository
+	BlockRepo            BlockingRepository
+	MuteRepo             MutingRepository
+	FollowRequestRepo    FollowRequestRepository
+	ReactionRepo         ReactionRepository
+	BookmarkRepo         BookmarkRepository
+	NotificationRepo     NotificationRepository
+	MentionRepo          MentionRepository
+	UploadRepo           UploadRepository
 }
 
 func InitRepository(d *sqlx.DB) *Repository {
@@ -19,5 +29,15 @@ func InitRepository(d *sqlx.DB) *Repository {
 		&ArticleTagsRepo{d},
 		&ArticleFavoritesRepoImpl{d},
 		&CommentRepoImpl{d},
+		&TagRepoImpl{d},
+		&TagFollowingRepoImpl{d},
+		&BlockingRepoImpl{d},
+		&MutingRepoImpl{d},
+		&FollowRequestRepoImpl{d},
+		&ReactionRepoImpl{d},
+		&BookmarkRepoImpl{d},
+		&NotificationRepoImpl{d},
+		&MentionRepoImpl{d},
+		&UploadRepoImpl{d},
 	}
 }
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TagFollowingRepoImpl struct {
+	db *sqlx.DB
+}
+
+type TagFollowingRepository interface {
+	InsertOne(context.Context, string, string) error
+	DeleteOne(context.Context, string, string) error
+	FindTagNames(context.Context, string) ([]string, error)
+}
+
+func (r *TagFollowingRepoImpl) InsertOne(ctx context.Context, username, tagName string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO tag_followings (username, tag_name) VALUES ($1, $2)"
+	if _, err = tx.ExecContext(ctx, query, username, tagName); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *TagFollowingRepoImpl) DeleteOne(ctx context.Context, username, tagName string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM tag_followings as tf WHERE tf.username = $1 AND tf.tag_name = $2"
+	if _, err = tx.ExecContext(ctx, query, username, tagName); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Every tag "username" follows
+func (r *TagFollowingRepoImpl) FindTagNames(ctx context.Context, username string) ([]string, error) {
+	names := []string{}
+	query := "SELECT tf.tag_name FROM tag_followings as tf WHERE tf.username = $1"
+	if err := r.db.SelectContext(ctx, &names, query, username); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type TagRepoImpl struct {
+	db *sqlx.DB
+}
+
+type TagRepository interface {
+	Find(context.Context, *model.FindTagsArgs) ([]*model.Tag, error)
+	FindOneByName(context.Context, string, string) (*model.Tag, error)
+}
+
+func (r *TagRepoImpl) Find(ctx context.Context, p *model.FindTagsArgs) ([]*model.Tag, error) {
+	tags := []*model.Tag{}
+	query := `
+	SELECT
+		t.name, t.description, t.articles_count, t.created_at, t.updated_at,
+		EXISTS (
+			SELECT 1 FROM tag_followings as tf
+			WHERE tf.tag_name = t.name AND tf.username = :username
+		) as "following"
+	FROM tags as t
+	WHERE t.articles_count > 0`
+
+	switch p.Sort {
+	case model.TagSortPopular:
+		query += " ORDER BY t.articles_count DESC, t.name ASC"
+	default:
+		query += " ORDER BY t.name ASC"
+	}
+
+	query += " LIMIT :limit"
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.SelectContext(ctx, &tags, p); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *TagRepoImpl) FindOneByName(ctx context.Context, username, name string) (*model.Tag, error) {
+	t := new(model.Tag)
+	query := `
+	SELECT
+		t.name, t.description, t.articles_count, t.created_at, t.updated_at,
+		EXISTS (
+			SELECT 1 FROM tag_followings as tf
+			WHERE tf.tag_name = t.name AND tf.username = $1
+		) as "following"
+	FROM tags as t WHERE t.name = $2`
+	if err := r.db.GetContext(ctx, t, query, username, name); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type UploadRepoImpl struct {
+	db *sqlx.DB
+}
+
+type UploadRepository interface {
+	InsertOne(context.Context, *model.Upload) error
+	FindOneByID(context.Context, string) (*model.Upload, error)
+	DeleteOne(context.Context, string) error
+}
+
+func (r *UploadRepoImpl) InsertOne(ctx context.Context, u *model.Upload) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO uploads (
+		username, purpose, key, thumbnail_key,
+		content_type, size, width, height
+	)
+	VALUES (
+		:username, :purpose, :key, :thumbnail_key,
+		:content_type, :size, :width, :height
+	)
+	RETURNING id, created_at`
+	stmt, err := tx.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if err = stmt.GetContext(ctx, u, u); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *UploadRepoImpl) FindOneByID(ctx context.Context, id string) (*model.Upload, error) {
+	u := new(model.Upload)
+	query := `
+	SELECT
+		up.id, up.username, up.purpose, up.key, up.thumbnail_key,
+		up.content_type, up.size, up.width, up.height, up.created_at
+	FROM uploads as up WHERE up.id = $1`
+	if err := r.db.GetContext(ctx, u, query, id); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *UploadRepoImpl) DeleteOne(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "DELETE FROM uploads as up WHERE up.id = $1"
+	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/ashalfarhan/realworld/model"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type UserRepoImpl struct {
@@ -16,6 +18,7 @@ type UserRepository interface {
 	FindOneByUsername(context.Context, string) (*model.User, error)
 	FindOne(context.Context, *model.FindUserArg) (*model.User, error)
 	UpdateOne(context.Context, *model.UpdateUserFields, *model.User) error
+	FindUsernames(context.Context, []string) ([]string, error)
 }
 
 // See https://go.dev/doc/database/execute-transactions
@@ -34,7 +37,7 @@ func (r *UserRepoImpl) InsertOne(ctx context.Context, d *model.RegisterUserField
 	query := `
 	INSERT INTO users (email, username, password)
 	VALUES (:email, :username, :password)
-	RETURNING users.id, users.bio, users.image`
+	RETURNING users.id, users.bio, users.image, users.version`
 	stmt, err := tx.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -52,7 +55,7 @@ func (r *UserRepoImpl) InsertOne(ctx context.Context, d *model.RegisterUserField
 func (r *UserRepoImpl) FindOneByUsername(ctx context.Context, username string) (*model.User, error) {
 	u := new(model.User)
 	query := `
-	SELECT id, email, username, bio, image, created_at, updated_at
+	SELECT id, email, username, bio, image, private, moderator, created_at, updated_at, version
 	FROM users WHERE users.username = $1`
 	if err := r.db.GetContext(ctx, u, query, username); err != nil {
 		return nil, err
@@ -63,7 +66,7 @@ func (r *UserRepoImpl) FindOneByUsername(ctx context.Context, username string) (
 func (r *UserRepoImpl) FindOne(ctx context.Context, d *model.FindUserArg) (*model.User, error) {
 	u := new(model.User)
 	query := `
-	SELECT id, email, username, password, bio, image FROM users 
+	SELECT id, email, username, password, bio, image, private, moderator FROM users 
 	WHERE users.email = $1 OR users.username = $2`
 	if err := r.db.GetContext(ctx, u, query, d.Email, d.Username); err != nil {
 		return nil, err
@@ -71,7 +74,9 @@ func (r *UserRepoImpl) FindOne(ctx context.Context, d *model.FindUserArg) (*mode
 	return u, nil
 }
 
+// Going public lets everyone waiting in, in the same transaction
 func (r *UserRepoImpl) UpdateOne(ctx context.Context, d *model.UpdateUserFields, u *model.User) error {
+	wasPrivate := u.Private
 	if v := d.Email; v != nil {
 		u.Email = *v
 	}
@@ -87,14 +92,19 @@ func (r *UserRepoImpl) UpdateOne(ctx context.Context, d *model.UpdateUserFields,
 	if v := d.Image; v.Set {
 		u.Image = v
 	}
+	if v := d.Private; v != nil {
+		u.Private = *v
+	}
 
 	query := `
 	UPDATE users
 	SET
 		email = :email, username = :username,
 		password = :password, bio = :bio,
-		image = :image, updated_at = NOW()
-	WHERE users.id = :id`
+		image = :image, private = :private,
+		updated_at = NOW(), version = users.version + 1
+	WHERE users.id = :id AND users.version = :version
+	RETURNING users.version, users.updated_at`
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
@@ -107,8 +117,27 @@ func (r *UserRepoImpl) UpdateOne(ctx context.Context, d *model.UpdateUserFields,
 	}
 	defer stmt.Close()
 
-	if _, err = stmt.ExecContext(ctx, u); err != nil {
+	// No row means the version moved on since the user was read
+	if err = stmt.QueryRowxContext(ctx, u).Scan(&u.Version, &u.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrStaleVersion
+		}
 		return err
 	}
+	if wasPrivate && !u.Private {
+		if err = approveAllFollowRequests(ctx, tx, u.Username); err != nil {
+			return err
+		}
+	}
 	return tx.Commit()
 }
+
+// Returns the ones of "usernames" that belong to an existing user
+func (r *UserRepoImpl) FindUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	found := []string{}
+	query := "SELECT us.username FROM users as us WHERE us.username = ANY($1)"
+	if err := r.db.SelectContext(ctx, &found, query, pq.Array(usernames)); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+func (s *ArticleService) BookmarkArticleBySlug(ctx context.Context, username, slug, collection string) (*model.Article, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST BookmarkArticle user:%q, slug:%q, collection:%q", username, slug, collection)
+	a, err := s.GetArticleBySlug(ctx, username, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bookmarkRepo.InsertOne(ctx, username, a.ID, collection); err != nil {
+		log.Warnln("Cannot BookmarkArticle reason:", err)
+		return nil, conduit.GeneralError
+	}
+	s.viewerCache.SetBookmarked(ctx, username, a.ID, true)
+	s.listCache.InvalidateUser(ctx, username)
+	a.Bookmarked = true
+	return a, nil
+}
+
+func (s *ArticleService) UnbookmarkArticleBySlug(ctx context.Context, username, slug string) (*model.Article, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE UnbookmarkArticle user:%q, slug:%q", username, slug)
+	a, err := s.GetArticleBySlug(ctx, username, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bookmarkRepo.DeleteOne(ctx, username, a.ID); err != nil {
+		log.Warnln("Cannot UnbookmarkArticle reason:", err)
+		return nil, conduit.GeneralError
+	}
+	s.viewerCache.SetBookmarked(ctx, username, a.ID, false)
+	s.listCache.InvalidateUser(ctx, username)
+	a.Bookmarked = false
+	return a, nil
+}
+
+// Returns a page of the reading list of args.Username
+func (s *ArticleService) GetBookmarks(ctx context.Context, args *model.FindArticlesArgs) (model.Articles, *model.ConduitError) {
+	args.Bookmarked = true
+	return s.GetArticles(ctx, args)
+}
+
+func (s *ArticleService) GetBookmarkCollections(ctx context.Context, username string) ([]*model.BookmarkCollection, *model.ConduitError) {
+	collections, err := s.bookmarkRepo.FindCollections(ctx, username)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot find bookmark collections of %q reason: %v", username, err)
+		return nil, conduit.GeneralError
+	}
+	return collections, nil
+}
+
+func (s *ArticleService) DeleteBookmarkCollection(ctx context.Context, username, name string) *model.ConduitError {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE BookmarkCollection user:%q, name:%q", username, name)
+	if err := s.bookmarkRepo.DeleteCollection(ctx, username, name); err != nil {
+		log.Warnln("Cannot DeleteBookmarkCollection reason:", err)
+		return conduit.GeneralError
+	}
+	s.listCache.InvalidateUser(ctx, username)
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ashalfarhan/realworld/cache/store"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+// Returns the shared cached article with the state of "username" overlaid,
+// or nil when either of them is not cached. The version is what an article
+// loaded instead has to be cached under.
+func (s *ArticleService) FindCachedArticle(ctx context.Context, slug, username string) (*model.Article, string) {
+	a, version := s.articleCache.FindOneBySlug(ctx, slug)
+	if a == nil || !s.viewerCache.Overlay(ctx, username, a) {
+		return nil, version
+	}
+	return a, version
+}
+
+// Cache an article loaded for "username", along with their state if it is not cached yet
+func (s *ArticleService) CacheArticle(ctx context.Context, slug, version, username string, a *model.Article) {
+	s.articleCache.SaveBySlug(ctx, slug, version, a)
+	if username == "" || s.viewerCache.Exists(ctx, username) {
+		return
+	}
+	if v := s.loadViewerState(ctx, username); v != nil {
+		s.viewerCache.Save(ctx, username, v)
+	}
+}
+
+// Errors only cost a cache miss, so they are logged and nothing is cached
+func (s *ArticleService) loadViewerState(ctx context.Context, username string) *store.ViewerState {
+	log := logger.GetCtx(ctx)
+	v := new(store.ViewerState)
+	var err error
+	if v.Favorites, err = s.favoritesRepo.FindArticleIDsByUsername(ctx, username); err != nil {
+		log.Warnf("Cannot find favorites of %q reason: %v", username, err)
+		return nil
+	}
+	if v.Bookmarks, err = s.bookmarkRepo.FindArticleIDsByUsername(ctx, username); err != nil {
+		log.Warnf("Cannot find bookmarks of %q reason: %v", username, err)
+		return nil
+	}
+	if v.Following, err = s.followRepo.FindAllFollowingUsernames(ctx, username); err != nil {
+		log.Warnf("Cannot find followings of %q reason: %v", username, err)
+		return nil
+	}
+	if v.Reactions, err = s.reactionRepo.FindArticleReactionsByUsername(ctx, username); err != nil {
+		log.Warnf("Cannot find reactions of %q reason: %v", username, err)
+		return nil
+	}
+	return v
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
+	"time"
 
 	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/config"
 	"github.com/ashalfarhan/realworld/model"
 	"github.com/ashalfarhan/realworld/utils/logger"
 )
@@ -18,10 +20,34 @@ func (s *ArticleService) CreateComment(ctx context.Context, d *model.CreateComme
 	if sErr != nil {
 		return nil, sErr
 	}
+	if blocked, err := isBlockedEither(ctx, s.blockRepo, username, ar.AuthorUsername); err != nil {
+		return nil, err
+	} else if blocked {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrBlocked)
+	}
+	if d.ParentID != nil {
+		// Not GetOneComment, a deleted parent still exists and is answered as such
+		parent, err := s.commentRepo.FindOneByID(ctx, *d.ParentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, conduit.BuildError(http.StatusNotFound, ErrNoCommentFound)
+			}
+			log.Warnf("Cannot find comment by id:%q reason: %v", *d.ParentID, err)
+			return nil, conduit.GeneralError
+		}
+		if parent.ArticleID != ar.ID {
+			return nil, conduit.BuildError(http.StatusNotFound, ErrNoCommentFound)
+		}
+		if parent.DeletedAt != nil {
+			return nil, conduit.BuildError(http.StatusBadRequest, ErrReplyDeletedComment)
+		}
+	}
+
 	c := &model.Comment{
 		Body:           d.Body,
 		AuthorUsername: username,
 		ArticleID:      ar.ID,
+		ParentID:       d.ParentID,
 	}
 
 	if err := s.commentRepo.InsertOne(ctx, c); err != nil {
@@ -29,32 +55,60 @@ func (s *ArticleService) CreateComment(ctx context.Context, d *model.CreateComme
 		return nil, conduit.GeneralError
 	}
 
+	s.notifier.Notify(ctx, &model.CreateNotificationArgs{
+		Recipient: ar.AuthorUsername,
+		Actor:     username,
+		Type:      model.NotificationComment,
+		ArticleID: &ar.ID,
+		CommentID: &c.ID,
+	})
+
 	u, err := s.userRepo.FindOneByUsername(ctx, c.AuthorUsername)
 	if err != nil {
 		log.Warnf("Cannot find username for %s, Reason: %v", c.AuthorUsername, err)
 		return nil, conduit.GeneralError
 	}
-	c.Author = u.Profile(false) // TODO: Change following dynamically
+	c.Author = u.Profile(false) // The author is the viewer, who cannot follow themselves
+	c.SetReactions(nil)
+	s.SaveCommentMentions(ctx, ar, c)
+	s.events.Publish(ctx, model.ArticleChannel(ar.ID), model.EventComment, username, c)
 	return c, nil
 }
 
-func (s *ArticleService) GetComments(ctx context.Context, slug, username string) ([]*model.Comment, *model.ConduitError) {
+// Returns a page of comment threads of an article, nested down to args.Depth levels,
+// and the total count of top level comments.
+func (s *ArticleService) GetComments(ctx context.Context, slug string, args *model.FindCommentsArgs) (model.Comments, int, *model.ConduitError) {
 	log := logger.GetCtx(ctx)
-	ar, sErr := s.GetArticleBySlug(ctx, "", slug)
+	ar, sErr := s.GetArticleBySlug(ctx, args.Username, slug)
 	if sErr != nil {
-		return nil, sErr
+		return nil, 0, sErr
 	}
-	comments, err := s.commentRepo.FindByArticleID(ctx, ar.ID)
+	args.ArticleID = ar.ID
+	comments, err := s.commentRepo.FindByArticleID(ctx, args)
 	if err != nil {
 		log.Warnf("Cannot find comment by article id:%q reason:%v", ar.ID, err)
-		return nil, conduit.GeneralError
+		return nil, 0, conduit.GeneralError
+	}
+	count, err := s.commentRepo.CountByArticleID(ctx, args)
+	if err != nil {
+		log.Warnf("Cannot count comment by article id:%q reason:%v", ar.ID, err)
+		return nil, 0, conduit.GeneralError
+	}
+	if err := s.PopulateCommentsReactions(ctx, comments, args.Username); err != nil {
+		return nil, 0, err
+	}
+	if args.Format == model.FormatHTML {
+		s.RenderCommentsBody(ctx, comments)
 	}
-	return comments, nil
+	return model.Comments(comments).Thread(), count, nil
 }
 
 func (s *ArticleService) GetOneComment(ctx context.Context, commentID string) (*model.Comment, *model.ConduitError) {
 	log := logger.GetCtx(ctx)
 	comm, err := s.commentRepo.FindOneByID(ctx, commentID)
+	if err == nil && comm.DeletedAt != nil {
+		err = sql.ErrNoRows
+	}
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, conduit.BuildError(http.StatusNotFound, ErrNoCommentFound)
@@ -80,3 +134,83 @@ func (s *ArticleService) DeleteCommentByID(ctx context.Context, commentID, userI
 	}
 	return nil
 }
+
+func (s *ArticleService) UpdateComment(ctx context.Context, d *model.UpdateCommentFields, username, slug, commentID string) (*model.Comment, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("PUT UpdateComment id:%q, user:%q", commentID, username)
+	ar, comm, sErr := s.findArticleComment(ctx, username, slug, commentID)
+	if sErr != nil {
+		return nil, sErr
+	}
+	if comm.AuthorUsername != username {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrNotAllowedUpdateComment)
+	}
+	if config.CommentEditWindow > 0 && time.Since(comm.CreatedAt) > config.CommentEditWindow {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrCommentEditWindow)
+	}
+
+	if comm.Body != d.Body {
+		comm.Body = d.Body
+		if err := s.commentRepo.UpdateOne(ctx, comm); err != nil {
+			log.Warnf("Cannot update comment id:%q reason: %v", commentID, err)
+			return nil, conduit.GeneralError
+		}
+		comm.Edited = true
+		s.SaveCommentMentions(ctx, ar, comm)
+	}
+
+	u, err := s.userRepo.FindOneByUsername(ctx, comm.AuthorUsername)
+	if err != nil {
+		log.Warnf("Cannot find username for %s, Reason: %v", comm.AuthorUsername, err)
+		return nil, conduit.GeneralError
+	}
+	comm.Author = u.Profile(false)
+	return comm, s.PopulateCommentsReactions(ctx, model.Comments{comm}, username)
+}
+
+// Prior versions of a comment are only visible to moderators
+func (s *ArticleService) GetCommentEdits(ctx context.Context, username, slug, commentID string) ([]*model.CommentEdit, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	u, err := s.userRepo.FindOneByUsername(ctx, username)
+	if err != nil {
+		log.Warnf("Cannot find username for %s, Reason: %v", username, err)
+		return nil, conduit.GeneralError
+	}
+	if !u.Moderator {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrNotModerator)
+	}
+
+	comm, sErr := s.getArticleComment(ctx, username, slug, commentID)
+	if sErr != nil {
+		return nil, sErr
+	}
+
+	edits, err := s.commentRepo.FindEditsByID(ctx, comm.ID)
+	if err != nil {
+		log.Warnf("Cannot find comment edits by id:%q reason: %v", commentID, err)
+		return nil, conduit.GeneralError
+	}
+	return edits, nil
+}
+
+// Find a comment that has to belong to the article with the given slug
+func (s *ArticleService) getArticleComment(ctx context.Context, username, slug, commentID string) (*model.Comment, *model.ConduitError) {
+	_, comm, err := s.findArticleComment(ctx, username, slug, commentID)
+	return comm, err
+}
+
+// Like getArticleComment, along with the article
+func (s *ArticleService) findArticleComment(ctx context.Context, username, slug, commentID string) (*model.Article, *model.Comment, *model.ConduitError) {
+	ar, err := s.GetArticleBySlug(ctx, username, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	comm, err := s.GetOneComment(ctx, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if comm.ArticleID != ar.ID {
+		return nil, nil, conduit.BuildError(http.StatusNotFound, ErrNoCommentFound)
+	}
+	return ar, comm, nil
+}
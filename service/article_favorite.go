@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"database/sql"
+	"net/http"
 
 	"github.com/ashalfarhan/realworld/conduit"
 	"github.com/ashalfarhan/realworld/model"
@@ -16,10 +17,24 @@ func (s *ArticleService) FavoriteArticleBySlug(ctx context.Context, username, sl
 	if err != nil {
 		return nil, err
 	}
+	if blocked, err := isBlockedEither(ctx, s.blockRepo, username, a.AuthorUsername); err != nil {
+		return nil, err
+	} else if blocked {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrBlocked)
+	}
 	if err := s.favoritesRepo.InsertOne(ctx, username, a.ID); err != nil {
 		log.Warnln("Cannot FavoriteArticle reason:", err)
 		return nil, conduit.GeneralError
 	}
+	s.articleCache.IncrFavoritesCount(ctx, slug, 1)
+	s.viewerCache.SetFavorited(ctx, username, a.ID, true)
+	s.listCache.Invalidate(ctx)
+	s.notifier.Notify(ctx, &model.CreateNotificationArgs{
+		Recipient: a.AuthorUsername,
+		Actor:     username,
+		Type:      model.NotificationFavorite,
+		ArticleID: &a.ID,
+	})
 	a.Favorited = true
 	a.FavoritesCount += 1
 	return a, nil
@@ -32,12 +47,19 @@ func (s *ArticleService) UnfavoriteArticleBySlug(ctx context.Context, username,
 	if err != nil {
 		return nil, err
 	}
-	if err := s.favoritesRepo.Delete(ctx, username, a.ID); err != nil {
-		log.Warnln("Cannot UnfavoriteArticle reason:", err)
+	deleted, dErr := s.favoritesRepo.Delete(ctx, username, a.ID)
+	if dErr != nil {
+		log.Warnln("Cannot UnfavoriteArticle reason:", dErr)
 		return nil, conduit.GeneralError
 	}
+	// Only the request that removed the favorite counts it, a.Favorited may be stale
+	if deleted == 1 {
+		s.articleCache.IncrFavoritesCount(ctx, slug, -1)
+		a.FavoritesCount -= 1
+	}
+	s.viewerCache.SetFavorited(ctx, username, a.ID, false)
+	s.listCache.Invalidate(ctx)
 	a.Favorited = false
-	a.FavoritesCount -= 1
 	return a, nil
 }
 
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/utils"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+// Store the users mentioned in the article body,
+// users mentioned for the first time are notified.
+// Best effort like notifying, the article is already saved by then,
+// failing the request would only make the client create it again.
+// The stored mentions are kept as they were when it fails.
+func (s *ArticleService) SaveArticleMentions(ctx context.Context, a *model.Article) {
+	usernames, err := s.resolveMentions(ctx, a.Body)
+	if err != nil {
+		return
+	}
+	if len(usernames) == 0 && len(a.Mentions) == 0 {
+		a.Mentions = usernames
+		return
+	}
+
+	added, err := s.mentionRepo.ReplaceArticleMentions(ctx, a.ID, usernames)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot replace mentions of article:%q, reason: %v", a.ID, err)
+		return
+	}
+	a.Mentions = usernames
+	s.notifyMentions(ctx, a, a.AuthorUsername, added, nil)
+}
+
+// Store the users mentioned in the comment body,
+// users mentioned for the first time are notified.
+// Best effort for the same reason as SaveArticleMentions.
+func (s *ArticleService) SaveCommentMentions(ctx context.Context, a *model.Article, c *model.Comment) {
+	usernames, err := s.resolveMentions(ctx, c.Body)
+	if err != nil {
+		return
+	}
+	if len(usernames) == 0 && len(c.Mentions) == 0 {
+		c.Mentions = usernames
+		return
+	}
+
+	added, err := s.mentionRepo.ReplaceCommentMentions(ctx, c.ArticleID, c.ID, usernames)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot replace mentions of comment:%q, reason: %v", c.ID, err)
+		return
+	}
+	c.Mentions = usernames
+	s.notifyMentions(ctx, a, c.AuthorUsername, added, &c.ID)
+}
+
+// Mentions of users that do not exist are left as plain text
+func (s *ArticleService) resolveMentions(ctx context.Context, body string) ([]string, error) {
+	mentions := utils.ParseMentions(body)
+	if len(mentions) == 0 {
+		return mentions, nil
+	}
+	usernames, err := s.userRepo.FindUsernames(ctx, mentions)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot find mentioned users:%v, reason: %v", mentions, err)
+		return nil, err
+	}
+	return usernames, nil
+}
+
+// The notification names the article, so only those who can read it are notified
+func (s *ArticleService) notifyMentions(ctx context.Context, a *model.Article, actor string, usernames []string, commentID *string) {
+	for _, username := range usernames {
+		// Notifying is best effort, but never past a block
+		if blocked, err := isBlockedEither(ctx, s.blockRepo, actor, username); err != nil || blocked {
+			continue
+		}
+		if !s.canReadArticle(ctx, a, username) {
+			continue
+		}
+		s.notifier.Notify(ctx, &model.CreateNotificationArgs{
+			Recipient: username,
+			Actor:     actor,
+			Type:      model.NotificationMention,
+			ArticleID: &a.ID,
+			CommentID: commentID,
+		})
+	}
+}
+
+// The rule of ArticleRepository.FindOneBySlug: the articles of a private author
+// are only for the author and their approved followers. A failing lookup denies.
+func (s *ArticleService) canReadArticle(ctx context.Context, a *model.Article, username string) bool {
+	if a.Author != nil && !a.Author.Private || username == a.AuthorUsername {
+		return true
+	}
+	ptr, err := s.followRepo.FindOneByIDs(ctx, username, a.AuthorUsername)
+	if err != nil && err != sql.ErrNoRows {
+		logger.GetCtx(ctx).Warnf("Cannot check if %q follows %q, reason: %v", username, a.AuthorUsername, err)
+	}
+	return ptr != nil && err == nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+func (s *ArticleService) ReactToArticle(ctx context.Context, username, slug, reaction string) (*model.Article, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST ReactToArticle user:%q, slug:%q, reaction:%q", username, slug, reaction)
+	if !IsValidReaction(reaction) {
+		return nil, conduit.BuildError(http.StatusUnprocessableEntity, ErrInvalidReaction)
+	}
+	a, err := s.GetArticleBySlug(ctx, username, slug)
+	if err != nil {
+		return nil, err
+	}
+	if blocked, err := isBlockedEither(ctx, s.blockRepo, username, a.AuthorUsername); err != nil {
+		return nil, err
+	} else if blocked {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrBlocked)
+	}
+
+	re := &model.Reaction{Username: username, ArticleID: &a.ID, Reaction: reaction}
+	if err := s.reactionRepo.InsertOne(ctx, re); err != nil {
+		switch err.Error() {
+		case repository.ErrDuplicateArticleReaction:
+			return nil, conduit.BuildError(http.StatusBadRequest, ErrAlreadyReacted)
+		default:
+			log.Warnln("Cannot insert to reaction repo reason:", err)
+			return nil, conduit.GeneralError
+		}
+	}
+	// The counts are cached with the article, the viewer's own reactions are not
+	s.articleCache.InvalidateBySlug(ctx, slug)
+	s.viewerCache.SetReacted(ctx, username, a.ID, reaction, true)
+	s.listCache.Invalidate(ctx)
+	return a, s.PopulateArticleReactions(ctx, a, username)
+}
+
+func (s *ArticleService) UnreactToArticle(ctx context.Context, username, slug, reaction string) (*model.Article, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE UnreactToArticle user:%q, slug:%q, reaction:%q", username, slug, reaction)
+	a, err := s.GetArticleBySlug(ctx, username, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	re := &model.Reaction{Username: username, ArticleID: &a.ID, Reaction: reaction}
+	if err := s.reactionRepo.DeleteOne(ctx, re); err != nil {
+		log.Warnln("Cannot delete from reaction repo reason:", err)
+		return nil, conduit.GeneralError
+	}
+	s.articleCache.InvalidateBySlug(ctx, slug)
+	s.viewerCache.SetReacted(ctx, username, a.ID, reaction, false)
+	s.listCache.Invalidate(ctx)
+	return a, s.PopulateArticleReactions(ctx, a, username)
+}
+
+func (s *ArticleService) ReactToComment(ctx context.Context, username, slug, commentID, reaction string) (*model.Comment, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST ReactToComment user:%q, comment:%q, reaction:%q", username, commentID, reaction)
+	if !IsValidReaction(reaction) {
+		return nil, conduit.BuildError(http.StatusUnprocessableEntity, ErrInvalidReaction)
+	}
+	comm, err := s.getArticleComment(ctx, username, slug, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked, err := isBlockedEither(ctx, s.blockRepo, username, comm.AuthorUsername); err != nil {
+		return nil, err
+	} else if blocked {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrBlocked)
+	}
+
+	re := &model.Reaction{Username: username, CommentID: &comm.ID, Reaction: reaction}
+	if err := s.reactionRepo.InsertOne(ctx, re); err != nil {
+		switch err.Error() {
+		case repository.ErrDuplicateCommentReaction:
+			return nil, conduit.BuildError(http.StatusBadRequest, ErrAlreadyReacted)
+		default:
+			log.Warnln("Cannot insert to reaction repo reason:", err)
+			return nil, conduit.GeneralError
+		}
+	}
+	return comm, s.PopulateCommentsReactions(ctx, model.Comments{comm}, username)
+}
+
+func (s *ArticleService) UnreactToComment(ctx context.Context, username, slug, commentID, reaction string) (*model.Comment, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE UnreactToComment user:%q, comment:%q, reaction:%q", username, commentID, reaction)
+	comm, err := s.getArticleComment(ctx, username, slug, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	re := &model.Reaction{Username: username, CommentID: &comm.ID, Reaction: reaction}
+	if err := s.reactionRepo.DeleteOne(ctx, re); err != nil {
+		log.Warnln("Cannot delete from reaction repo reason:", err)
+		return nil, conduit.GeneralError
+	}
+	return comm, s.PopulateCommentsReactions(ctx, model.Comments{comm}, username)
+}
+
+func (s *ArticleService) PopulateArticleReactions(ctx context.Context, a *model.Article, username string) *model.ConduitError {
+	return s.PopulateArticlesReactions(ctx, model.Articles{a}, username)
+}
+
+// Load the reactions of a page of articles in one go
+func (s *ArticleService) PopulateArticlesReactions(ctx context.Context, articles model.Articles, username string) *model.ConduitError {
+	if len(articles) == 0 {
+		return nil
+	}
+	ids := make([]string, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	counts, err := s.reactionRepo.FindByArticleIDs(ctx, ids, username)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot find reactions of articles ids:%v reason: %v", ids, err)
+		return conduit.GeneralError
+	}
+	grouped := model.GroupReactions(counts)
+	for _, a := range articles {
+		a.SetReactions(grouped[a.ID])
+	}
+	return nil
+}
+
+// Load the reactions of a flat list of comments in one go
+func (s *ArticleService) PopulateCommentsReactions(ctx context.Context, comments model.Comments, username string) *model.ConduitError {
+	ids := make([]string, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+	counts, err := s.reactionRepo.FindByCommentIDs(ctx, ids, username)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot find reactions of comments reason: %v", err)
+		return conduit.GeneralError
+	}
+	grouped := model.GroupReactions(counts)
+	for _, c := range comments {
+		c.SetReactions(grouped[c.ID])
+	}
+	return nil
+}
+
+func IsValidReaction(reaction string) bool {
+	for _, r := range config.Reactions {
+		if r == reaction {
+			return true
+		}
+	}
+	return false
+}
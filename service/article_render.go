@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/utils/logger"
+	"github.com/ashalfarhan/realworld/utils/markdown"
+)
+
+// Render the markdown body of an article unless it already is, like when it comes from the cache.
+// A failed render leaves the HTML empty, the raw body is still returned.
+func (s *ArticleService) RenderArticleBody(ctx context.Context, a *model.Article) {
+	if a.BodyHTML != "" || a.Body == "" {
+		return
+	}
+	html, err := markdown.Render(a.Body, a.Mentions)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot render body of article:%q, reason: %v", a.ID, err)
+		return
+	}
+	a.BodyHTML = html
+}
+
+// Deleted comments have nothing to render
+func (s *ArticleService) RenderCommentsBody(ctx context.Context, comments model.Comments) {
+	for _, c := range comments {
+		if c.DeletedAt != nil || c.BodyHTML != "" {
+			continue
+		}
+		html, err := markdown.Render(c.Body, c.Mentions)
+		if err != nil {
+			logger.GetCtx(ctx).Warnf("Cannot render body of comment:%q, reason: %v", c.ID, err)
+			continue
+		}
+		c.BodyHTML = html
+	}
+}
+
+const (
+	readingWordsPerMinute = 200
+	excerptLength         = 200
+)
+
+// The excerpt is the description, or the start of the body when there is none
+func (s *ArticleService) ComputeArticleStats(body, description string) model.ArticleStats {
+	words := len(strings.Fields(markdown.PlainText(body)))
+	stats := model.ArticleStats{
+		WordCount:          words,
+		ReadingTimeMinutes: (words + readingWordsPerMinute - 1) / readingWordsPerMinute,
+		Excerpt:            description,
+	}
+	if description == "" {
+		stats.Excerpt = markdown.Excerpt(body, excerptLength)
+	}
+	return stats
+}
@@ -5,14 +5,17 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ashalfarhan/realworld/cache/store"
 	"github.com/ashalfarhan/realworld/conduit"
 	"github.com/ashalfarhan/realworld/model"
 	"github.com/ashalfarhan/realworld/persistence/repository"
+	"github.com/ashalfarhan/realworld/utils"
 	"github.com/ashalfarhan/realworld/utils/logger"
 	"github.com/gosimple/slug"
 	"github.com/matoous/go-nanoid/v2"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -25,17 +28,41 @@ type ArticleService struct {
 	tagsRepo      repository.ArticleTagsRepository
 	favoritesRepo repository.ArticleFavoritesRepository
 	commentRepo   repository.CommentRepository
+	tagRepo       repository.TagRepository
+	tagFollowRepo repository.TagFollowingRepository
+	blockRepo     repository.BlockingRepository
+	reactionRepo  repository.ReactionRepository
+	bookmarkRepo  repository.BookmarkRepository
+	mentionRepo   repository.MentionRepository
+	followRepo    repository.FollowingRepository
 	articleCache  store.ArticleStore
+	viewerCache   store.ViewerStore
+	listCache     store.ListStore
+	listFlight    *singleflight.Group
+	notifier      *NotificationService
+	events        *EventService
 }
 
-func NewArticleService(repo *repository.Repository, store *store.CacheStore) *ArticleService {
+func NewArticleService(repo *repository.Repository, store *store.CacheStore, notifier *NotificationService, events *EventService) *ArticleService {
 	return &ArticleService{
 		repo.ArticleRepo,
 		repo.UserRepo,
 		repo.ArticleTagsRepo,
 		repo.ArticleFavoritesRepo,
 		repo.CommentRepo,
+		repo.TagRepo,
+		repo.TagFollowRepo,
+		repo.BlockRepo,
+		repo.ReactionRepo,
+		repo.BookmarkRepo,
+		repo.MentionRepo,
+		repo.FollowRepo,
 		store.ArticleStore,
+		store.ViewerStore,
+		store.ListStore,
+		new(singleflight.Group),
+		notifier,
+		events,
 	}
 }
 
@@ -43,6 +70,7 @@ func (s *ArticleService) CreateArticle(ctx context.Context, d *model.CreateArtic
 	log := logger.GetCtx(ctx)
 	log.Infof("POST CreateArticle dto:%+v, user:%q", d, username)
 	d.Slug = s.CreateSlug(d.Title)
+	d.Stats = s.ComputeArticleStats(d.Body, d.Description)
 	a, err := s.articleRepo.InsertOne(ctx, d, username)
 	if err != nil {
 		log.Warnf("Cannot insert article args:%+v reason:%v", a, err)
@@ -65,68 +93,118 @@ func (s *ArticleService) CreateArticle(ctx context.Context, d *model.CreateArtic
 		return nil, conduit.GeneralError
 	}
 	a.Author = u.Profile(false) // TODO: Change following dynamically 
+	s.SaveArticleMentions(ctx, a)
+	s.listCache.Invalidate(ctx)
+	channels := []string{model.AuthorChannel(a.AuthorUsername)}
+	if !u.Private {
+		for _, tag := range a.TagList {
+			channels = append(channels, model.TagChannel(tag))
+		}
+	}
+	s.events.PublishAll(ctx, channels, model.EventArticle, a.AuthorUsername, a.Serialize())
 	return a, nil
 }
 
 func (s *ArticleService) GetArticleBySlug(ctx context.Context, username, slug string) (*model.Article, *model.ConduitError) {
-	log := logger.GetCtx(ctx)
-	if cached := s.articleCache.FindOneBySlug(ctx, slug, username); cached != nil {
+	cached, version := s.FindCachedArticle(ctx, slug, username)
+	if cached != nil {
+		s.RenderArticleBody(ctx, cached)
 		return cached, nil
 	}
 
+	ar, err := s.findArticleBySlug(ctx, username, slug)
+	if err != nil {
+		return nil, err
+	}
+	s.RenderArticleBody(ctx, ar)
+	s.CacheArticle(ctx, slug, version, username, ar)
+	return ar, nil
+}
+
+// Always from the database, writes must not act on a stale cached copy
+func (s *ArticleService) findArticleBySlug(ctx context.Context, username, slug string) (*model.Article, *model.ConduitError) {
 	ar, err := s.articleRepo.FindOneBySlug(ctx, username, slug)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, conduit.BuildError(http.StatusNotFound, ErrNoArticleFound)
 		}
-		log.Warnln("Failed to get article by slug:", err)
+		logger.GetCtx(ctx).Warnln("Failed to get article by slug:", err)
 		return nil, conduit.GeneralError
 	}
 
 	if err := s.PopulateArticleField(ctx, ar, username); err != nil {
 		return nil, err
 	}
-	s.articleCache.SaveBySlug(ctx, slug, username, ar)
 	return ar, nil
 }
 
 func (s *ArticleService) GetArticles(ctx context.Context, args *model.FindArticlesArgs) (model.Articles, *model.ConduitError) {
-	log := logger.GetCtx(ctx)
-	articles, err := s.articleRepo.Find(ctx, args)
-	if err != nil {
-		log.Warnln("Cannot find articles:", err)
-		return nil, conduit.GeneralError
-	}
+	return s.FindArticlesCached(ctx, args)
+}
 
-	for _, a := range articles {
-		if err := s.PopulateArticleField(ctx, a, args.Username); err != nil {
+func (s *ArticleService) GetArticlesFeed(ctx context.Context, args *model.FindArticlesArgs) (model.Articles, *model.ConduitError) {
+	return s.FindArticlesCached(ctx, args)
+}
+
+// Bounds a shared list load, which no single request can cancel
+const listLoadTimeout = 5 * time.Second
+
+// Concurrent misses of the same page share a single load.
+// The load outlives whichever request started it, each caller
+// only stops waiting for it when its own request ends.
+func (s *ArticleService) FindArticlesCached(ctx context.Context, args *model.FindArticlesArgs) (model.Articles, *model.ConduitError) {
+	if cached := s.listCache.Find(ctx, args); cached != nil {
+		return cached, nil
+	}
+	ch := s.listFlight.DoChan(store.ListKey(args), func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(utils.DetachCtx(ctx), listLoadTimeout)
+		defer cancel()
+		articles, err := s.FindArticles(loadCtx, args)
+		if err != nil {
+			// Not an error, it is handed to every waiter as the result
+			return err, nil
+		}
+		s.listCache.Save(loadCtx, args, articles)
+		return articles, nil
+	})
+	select {
+	case <-ctx.Done():
+		logger.GetCtx(ctx).Warnf("Stopped waiting for articles args:%+v reason: %v", args, ctx.Err())
+		return nil, conduit.GeneralError
+	case res := <-ch:
+		if err, ok := res.Val.(*model.ConduitError); ok {
 			return nil, err
 		}
+		return res.Val.(model.Articles), nil
 	}
-	// TODO: Caching
-	return articles, nil
 }
 
-func (s *ArticleService) GetArticlesFeed(ctx context.Context, args *model.FindArticlesArgs) (model.Articles, *model.ConduitError) {
+func (s *ArticleService) FindArticles(ctx context.Context, args *model.FindArticlesArgs) (model.Articles, *model.ConduitError) {
 	log := logger.GetCtx(ctx)
 	articles, err := s.articleRepo.Find(ctx, args)
 	if err != nil {
-		log.Warnln("Cannot find feed articles:", err)
+		log.Warnf("Cannot find articles args:%+v reason: %v", args, err)
 		return nil, conduit.GeneralError
 	}
 
 	for _, a := range articles {
-		if err := s.PopulateArticleField(ctx, a, args.Username); err != nil {
-			return nil, err
+		if a.TagList, err = s.tagsRepo.FindArticleTagsByID(ctx, a.ID); err != nil {
+			log.Warnf("Cannot find tags of article id:%q reason: %v", a.ID, err)
+			return nil, conduit.GeneralError
 		}
+		if args.Format == model.FormatHTML {
+			s.RenderArticleBody(ctx, a)
+		}
+	}
+	if err := s.PopulateArticlesReactions(ctx, articles, args.Username); err != nil {
+		return nil, err
 	}
-	// TODO: Caching
 	return articles, nil
 }
 
 func (s *ArticleService) DeleteArticle(ctx context.Context, slug, username string) *model.ConduitError {
 	log := logger.GetCtx(ctx)
-	a, err := s.GetArticleBySlug(ctx, username, slug)
+	a, err := s.findArticleBySlug(ctx, username, slug)
 	if err != nil {
 		return err
 	}
@@ -138,13 +216,15 @@ func (s *ArticleService) DeleteArticle(ctx context.Context, slug, username strin
 		log.Warnf("Failed to delete article by slug:%q, reason: %v", slug, err)
 		return conduit.GeneralError
 	}
+	s.articleCache.InvalidateBySlug(ctx, slug)
+	s.listCache.Invalidate(ctx)
 	return nil
 }
 
 func (s *ArticleService) UpdateArticleBySlug(ctx context.Context, username, slug string, d *model.UpdateArticleFields) (*model.Article, *model.ConduitError) {
 	log := logger.GetCtx(ctx)
 	log.Infof("UpdateArticleBySlug user:%q, slug:%q, dto:%+v", username, slug, d)
-	ar, err := s.GetArticleBySlug(ctx, username, slug)
+	ar, err := s.findArticleBySlug(ctx, username, slug)
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +232,9 @@ func (s *ArticleService) UpdateArticleBySlug(ctx context.Context, username, slug
 	if ar.AuthorUsername != username {
 		return nil, conduit.BuildError(http.StatusForbidden, ErrNotAllowedUpdateArticle)
 	}
+	if err := checkVersion(ar.Version, d.IfMatch, d.Version); err != nil {
+		return nil, err
+	}
 
 	// Updating title will update the slug
 	if v := d.Title; v != nil {
@@ -159,10 +242,41 @@ func (s *ArticleService) UpdateArticleBySlug(ctx context.Context, username, slug
 		d.Slug = &newSlug
 	}
 
-	if err := s.articleRepo.UpdateOneBySlug(ctx, d, ar); err != nil {
+	if d.Body != nil || d.Description != nil {
+		body, description := ar.Body, ar.Description
+		if v := d.Body; v != nil {
+			body = *v
+		}
+		if v := d.Description; v != nil {
+			description = *v
+		}
+		stats := s.ComputeArticleStats(body, description)
+		d.Stats = &stats
+	}
+
+	var tags *repository.ArticleTagsDiff
+	if v := d.TagList; v != nil {
+		tags = DiffArticleTags(ar, *v)
+	}
+	if err := s.articleRepo.UpdateOneBySlug(ctx, d, ar, tags); err != nil {
+		if err == repository.ErrStaleVersion {
+			return nil, staleVersionError(d.IfMatch)
+		}
 		log.Warnf("Cannot UpdateOneBySlug slug:%s, payload:%+v, reason: %v", slug, d, err)
 		return nil, conduit.GeneralError
 	}
+	// The old slug, a new one has nothing cached yet
+	defer s.articleCache.InvalidateBySlug(ctx, slug)
+	defer s.listCache.Invalidate(ctx)
+
+	if v := d.TagList; v != nil {
+		ar.TagList = *v
+	}
+	if d.Body != nil {
+		s.SaveArticleMentions(ctx, ar)
+		ar.BodyHTML = ""
+		s.RenderArticleBody(ctx, ar)
+	}
 	return ar, nil
 }
 
@@ -173,7 +287,7 @@ func (s *ArticleService) PopulateArticleField(ctx context.Context, a *model.Arti
 	}
 	a.TagList = tags
 	// a.Author.Following
-	return nil
+	return s.PopulateArticleReactions(ctx, a, username)
 }
 
 // TODO: Can be moved to utils
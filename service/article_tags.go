@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"net/http"
 
 	"github.com/ashalfarhan/realworld/conduit"
 	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
 	"github.com/ashalfarhan/realworld/utils/logger"
 )
 
@@ -18,3 +20,92 @@ func (s *ArticleService) GetAllTags(ctx context.Context) ([]string, *model.Condu
 	}
 	return tags, nil
 }
+
+func (s *ArticleService) GetTags(ctx context.Context, args *model.FindTagsArgs) ([]*model.Tag, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	tags, err := s.tagRepo.Find(ctx, args)
+	if err != nil {
+		log.Warnf("Cannot find tags args:%+v reason: %v", args, err)
+		return nil, conduit.GeneralError
+	}
+	return tags, nil
+}
+
+func (s *ArticleService) GetTag(ctx context.Context, username, name string) (*model.Tag, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	t, err := s.tagRepo.FindOneByName(ctx, username, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, conduit.BuildError(http.StatusNotFound, ErrNoTagFound)
+		}
+		log.Warnf("Cannot find tag by name:%q reason: %v", name, err)
+		return nil, conduit.GeneralError
+	}
+	return t, nil
+}
+
+func (s *ArticleService) FollowTag(ctx context.Context, username, name string) (*model.Tag, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST FollowTag tag:%q, user:%q", name, username)
+	t, err := s.GetTag(ctx, username, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tagFollowRepo.InsertOne(ctx, username, t.Name); err != nil {
+		switch err.Error() {
+		case repository.ErrDuplicateTagFollow:
+			return nil, conduit.BuildError(http.StatusBadRequest, ErrAlreadyFollowTag)
+		default:
+			log.Warnln("Cannot insert to tag follow repo reason:", err)
+			return nil, conduit.GeneralError
+		}
+	}
+	s.listCache.InvalidateUser(ctx, username)
+	t.Following = true
+	return t, nil
+}
+
+func (s *ArticleService) UnfollowTag(ctx context.Context, username, name string) (*model.Tag, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE UnfollowTag tag:%q, user:%q", name, username)
+	t, err := s.GetTag(ctx, username, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tagFollowRepo.DeleteOne(ctx, username, t.Name); err != nil {
+		log.Warnln("Cannot delete from tag follow repo reason:", err)
+		return nil, conduit.GeneralError
+	}
+	s.listCache.InvalidateUser(ctx, username)
+	t.Following = false
+	return t, nil
+}
+
+// What replacing the tags of an article with tagList takes,
+// only the tags that are actually added or removed.
+func DiffArticleTags(a *model.Article, tagList []string) *repository.ArticleTagsDiff {
+	current := make(map[string]bool, len(a.TagList))
+	for _, tag := range a.TagList {
+		current[tag] = true
+	}
+
+	added := []repository.InsertArticleTagsArgs{}
+	for _, tag := range tagList {
+		if current[tag] {
+			delete(current, tag)
+			continue
+		}
+		added = append(added, repository.InsertArticleTagsArgs{ArticleID: a.ID, TagName: tag})
+	}
+
+	removed := []string{}
+	for _, tag := range a.TagList {
+		if current[tag] {
+			removed = append(removed, tag)
+		}
+	}
+
+	return &repository.ArticleTagsDiff{Added: added, Removed: removed}
+}
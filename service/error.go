@@ -6,13 +6,30 @@ import (
 
 var (
 	// UserService Error
-	ErrNoUserFound   = errors.New("no user found")
-	ErrEmailExist    = errors.New("email already exist")
-	ErrUsernameExist = errors.New("username already exist")
-	ErrIdentityExist = errors.New("username or email is in use")
-	ErrSelfFollow    = errors.New("you cannot follow your self")
-	ErrSelfUnfollow  = errors.New("you cannot unfollow your self")
-	ErrAlreadyFollow = errors.New("you are already follow this user")
+	ErrNoUserFound      = errors.New("no user found")
+	ErrEmailExist       = errors.New("email already exist")
+	ErrUsernameExist    = errors.New("username already exist")
+	ErrIdentityExist    = errors.New("username or email is in use")
+	ErrSelfFollow       = errors.New("you cannot follow your self")
+	ErrSelfUnfollow     = errors.New("you cannot unfollow your self")
+	ErrAlreadyFollow    = errors.New("you are already follow this user")
+	ErrSelfBlock        = errors.New("you cannot block your self")
+	ErrAlreadyBlock     = errors.New("you are already block this user")
+	ErrSelfMute         = errors.New("you cannot mute your self")
+	ErrAlreadyMute      = errors.New("you are already mute this user")
+	ErrBlocked          = errors.New("you cannot interact with this user")
+	ErrAlreadyRequested = errors.New("you are already request to follow this user")
+	ErrNoFollowRequest  = errors.New("no follow request found")
+
+	// NotificationService Error
+	ErrNoNotificationFound = errors.New("no notification found")
+
+	// UploadService Error
+	ErrUploadTooLarge         = errors.New("file is too large")
+	ErrUnsupportedUpload      = errors.New("file must be a jpeg, png or gif image")
+	ErrUploadDimensions       = errors.New("image dimensions are out of bounds")
+	ErrNoUploadFound          = errors.New("no upload found")
+	ErrNotAllowedDeleteUpload = errors.New("you cannot delete this upload")
 
 	// AuthService Error
 	ErrInvalidClaim    = errors.New("invalid claim")
@@ -24,4 +41,16 @@ var (
 	ErrNotAllowedUpdateArticle = errors.New("you cannot edit this article")
 	ErrNoCommentFound          = errors.New("no comment found")
 	ErrNotAllowedDeleteComment = errors.New("you cannot delete this comment")
+	ErrReplyDeletedComment     = errors.New("you cannot reply to a deleted comment")
+	ErrNotAllowedUpdateComment = errors.New("you cannot edit this comment")
+	ErrCommentEditWindow       = errors.New("this comment can no longer be edited")
+	ErrNotModerator            = errors.New("only moderators can do this")
+	ErrNoTagFound              = errors.New("no tag found")
+	ErrAlreadyFollowTag        = errors.New("you are already follow this tag")
+	ErrInvalidReaction         = errors.New("reaction is not supported")
+	ErrAlreadyReacted          = errors.New("you are already react with this reaction")
+
+	// Optimistic concurrency of article and user updates
+	ErrVersionConflict    = errors.New("it was updated in the meantime, reload and try again")
+	ErrPreconditionFailed = errors.New("if-match does not match the current version")
 )
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ashalfarhan/realworld/cache/store"
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	"github.com/ashalfarhan/realworld/utils/logger"
+	"github.com/google/uuid"
+)
+
+type EventService struct {
+	events        store.EventStore
+	followRepo    repository.FollowingRepository
+	tagFollowRepo repository.TagFollowingRepository
+	blockRepo     repository.BlockingRepository
+	muteRepo      repository.MutingRepository
+}
+
+func NewEventService(repo *repository.Repository, store *store.CacheStore) *EventService {
+	return &EventService{
+		events:        store.EventStore,
+		followRepo:    repo.FollowRepo,
+		tagFollowRepo: repo.TagFollowRepo,
+		blockRepo:     repo.BlockRepo,
+		muteRepo:      repo.MuteRepo,
+	}
+}
+
+// Publishing is best effort, a failure never fails the action that triggered it
+func (s *EventService) Publish(ctx context.Context, channel, eventType, actor string, data interface{}) {
+	s.PublishAll(ctx, []string{channel}, eventType, actor, data)
+}
+
+// Publish one event to several channels, subscribers of more than one
+// can tell it is the same by its ID
+func (s *EventService) PublishAll(ctx context.Context, channels []string, eventType, actor string, data interface{}) {
+	log := logger.GetCtx(ctx)
+	b, err := json.Marshal(data)
+	if err != nil {
+		log.Warnf("Cannot encode %q event, reason: %v", eventType, err)
+		return
+	}
+	e := &model.Event{ID: uuid.NewString(), Type: eventType, Actor: actor, Data: b}
+	for _, channel := range channels {
+		if err := s.events.Publish(ctx, channel, e); err != nil {
+			log.Warnf("Cannot publish %q event to %q, reason: %v", eventType, channel, err)
+		}
+	}
+}
+
+// Listen to the notifications of "username", the new articles of the authors
+// and tags they follow and, when articleID is set, the new comments on that article.
+// The followings are only read here, Resubscribe picks up later changes.
+func (s *EventService) Subscribe(ctx context.Context, username, articleID string) (<-chan *model.Event, func(), *model.ConduitError) {
+	channels, err := s.channels(ctx, username, articleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, cancel := s.events.Subscribe(channels...)
+	return events, cancel, nil
+}
+
+// Read the followings of "username" again and listen to what they follow now.
+// The subscription is kept as it was when they cannot be read.
+func (s *EventService) Resubscribe(ctx context.Context, events <-chan *model.Event, username, articleID string) {
+	if channels, err := s.channels(ctx, username, articleID); err == nil {
+		s.events.Resubscribe(events, channels...)
+	}
+}
+
+func (s *EventService) channels(ctx context.Context, username, articleID string) ([]string, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	authors, err := s.followRepo.FindFollowingUsernames(ctx, username)
+	if err != nil {
+		log.Warnf("Cannot find followings of %q, reason: %v", username, err)
+		return nil, conduit.GeneralError
+	}
+	tags, err := s.tagFollowRepo.FindTagNames(ctx, username)
+	if err != nil {
+		log.Warnf("Cannot find followed tags of %q, reason: %v", username, err)
+		return nil, conduit.GeneralError
+	}
+
+	channels := []string{model.UserChannel(username)}
+	for _, a := range authors {
+		channels = append(channels, model.AuthorChannel(a))
+	}
+	for _, t := range tags {
+		channels = append(channels, model.TagChannel(t))
+	}
+	if articleID != "" {
+		channels = append(channels, model.ArticleChannel(articleID))
+	}
+	return channels, nil
+}
+
+// Events from users "username" has muted, or is blocking or blocked by, are not delivered.
+// An event whose blocks cannot be checked is dropped.
+func (s *EventService) IsVisible(ctx context.Context, username string, e *model.Event) bool {
+	if e.Actor == "" || e.Actor == username {
+		return true
+	}
+	if blocked, err := isBlockedEither(ctx, s.blockRepo, username, e.Actor); err != nil || blocked {
+		return false
+	}
+	ptr, err := s.muteRepo.FindOneByIDs(ctx, username, e.Actor)
+	return ptr == nil || err != nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+	events           *EventService
+}
+
+func NewNotificationService(repo *repository.Repository, events *EventService) *NotificationService {
+	return &NotificationService{
+		notificationRepo: repo.NotificationRepo,
+		events:           events,
+	}
+}
+
+// Notifying is best effort, a failure never fails the action that triggered it
+func (s *NotificationService) Notify(ctx context.Context, args *model.CreateNotificationArgs) {
+	inserted, err := s.notificationRepo.InsertOne(ctx, args)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot insert notification args:%+v, reason: %v", args, err)
+		return
+	}
+	if inserted {
+		s.events.Publish(ctx, model.UserChannel(args.Recipient), model.EventNotification, args.Actor, args)
+	}
+}
+
+// Returns a page of grouped notifications and the count of unread groups
+func (s *NotificationService) GetNotifications(ctx context.Context, args *model.FindNotificationsArgs) ([]*model.Notification, int, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	notifications, err := s.notificationRepo.Find(ctx, args)
+	if err != nil {
+		log.Warnf("Cannot find notifications args:%+v, reason: %v", args, err)
+		return nil, 0, conduit.GeneralError
+	}
+	unread, err := s.notificationRepo.CountUnread(ctx, args.Username)
+	if err != nil {
+		log.Warnf("Cannot count unread notifications of %q, reason: %v", args.Username, err)
+		return nil, 0, conduit.GeneralError
+	}
+	for _, n := range notifications {
+		n.TrimActors()
+	}
+	return notifications, unread, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, username, id string) *model.ConduitError {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST MarkRead notification:%q, user:%q", id, username)
+	if err := s.notificationRepo.MarkRead(ctx, username, id); err != nil {
+		if err == sql.ErrNoRows {
+			return conduit.BuildError(http.StatusNotFound, ErrNoNotificationFound)
+		}
+		log.Warnln("Cannot mark notification as read reason:", err)
+		return conduit.GeneralError
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, username string) *model.ConduitError {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST MarkAllRead user:%q", username)
+	if err := s.notificationRepo.MarkAllRead(ctx, username); err != nil {
+		log.Warnln("Cannot mark all notifications as read reason:", err)
+		return conduit.GeneralError
+	}
+	return nil
+}
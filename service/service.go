@@ -3,21 +3,26 @@ package service
 import (
 	"github.com/ashalfarhan/realworld/cache/store"
 	"github.com/ashalfarhan/realworld/persistence/repository"
-	"github.com/go-redis/redis/v8"
+	"github.com/ashalfarhan/realworld/storage"
 	"github.com/jmoiron/sqlx"
 )
 
 type Service struct {
-	UserService    *UserService
-	AuthService    *AuthService
-	ArticleService *ArticleService
+	UserService         *UserService
+	AuthService         *AuthService
+	ArticleService      *ArticleService
+	NotificationService *NotificationService
+	EventService        *EventService
+	UploadService       *UploadService
 }
 
-func InitService(d *sqlx.DB, s *redis.Client) *Service {
+func InitService(d *sqlx.DB, store *store.CacheStore, blobs storage.BlobStore) *Service {
 	repo := repository.InitRepository(d)
-	store := store.NewCacheStore(s)
-	userService := NewUserService(repo)
-	articleService := NewArticleService(repo, store)
+	eventService := NewEventService(repo, store)
+	notificationService := NewNotificationService(repo, eventService)
+	userService := NewUserService(repo, store, notificationService)
+	articleService := NewArticleService(repo, store, notificationService, eventService)
 	authService := NewAuthService(userService)
-	return &Service{userService, authService, articleService}
+	uploadService := NewUploadService(repo, blobs)
+	return &Service{userService, authService, articleService, notificationService, eventService, uploadService}
 }
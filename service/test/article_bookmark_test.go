@@ -0,0 +1,28 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBookmarks(t *testing.T) {
+	as := assert.New(t)
+	args := &model.FindArticlesArgs{Username: "username", Collection: "later", Limit: 5}
+
+	listStoreMock.On("Find", mock.Anything, args).Return(model.Articles(nil)).Once()
+	listStoreMock.On("Save", mock.Anything, args, model.Articles{}).Once()
+	articleRepoMock.On("Find", mock.Anything, mock.Anything).Return(model.Articles{}, nil).Once()
+	articles, err := articleService.GetBookmarks(tctx, args)
+	articleRepoMock.AssertCalled(t, "Find", mock.Anything, &model.FindArticlesArgs{
+		Username:   "username",
+		Collection: "later",
+		Bookmarked: true,
+		Limit:      5,
+	})
+
+	as.Nil(err)
+	as.Empty(articles)
+}
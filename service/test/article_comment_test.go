@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	. "github.com/ashalfarhan/realworld/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCommentsThreads(t *testing.T) {
+	as := assert.New(t)
+	root, reply, missing := "root", "reply", "missing"
+	deletedAt := time.Now()
+	args := &model.FindCommentsArgs{Username: "username", Depth: 3, Limit: 20}
+	flat := []*model.Comment{
+		{ID: root, Body: "gone", RepliesCount: 1, DeletedAt: &deletedAt, Author: &model.ProfileRs{Username: "author"}},
+		{ID: "other", Body: "top level"},
+		{ID: reply, Body: "reply", ParentID: &root, Depth: 2},
+		{ID: "orphan", Body: "orphan", ParentID: &missing, Depth: 3},
+	}
+
+	articleStoreMock.On("FindOneBySlug", mock.Anything, "slug").Return(&model.Article{ID: "article"}, "v1").Once()
+	commentRepoMock.On("FindByArticleID", mock.Anything, args).Return(flat, nil).Once()
+	commentRepoMock.On("CountByArticleID", mock.Anything, args).Return(2, nil).Once()
+	reactionRepoMock.On("FindByCommentIDs", mock.Anything, []string{root, "other", reply, "orphan"}, args.Username).Return([]*model.ReactionCount{
+		{TargetID: reply, Reaction: "like", Count: 2, Reacted: true},
+	}, nil).Once()
+	comments, count, err := articleService.GetComments(tctx, "slug", args)
+	commentRepoMock.AssertExpectations(t)
+
+	as.Nil(err)
+	as.Equal(2, count)
+	as.Equal("article", args.ArticleID)
+	as.Len(comments, 2, "Only top level comments should be at the root")
+	as.True(comments[0].Deleted)
+	as.Equal(model.DeletedCommentBody, comments[0].Body)
+	as.Nil(comments[0].Author, "Deleted comment should not expose its author")
+	as.Len(comments[0].Replies, 1)
+	as.Equal(reply, comments[0].Replies[0].ID)
+	as.Equal(map[string]int{"like": 2}, comments[0].Replies[0].Reactions)
+	as.Equal([]string{"like"}, comments[0].Replies[0].ViewerReactions)
+	as.Empty(comments[1].Reactions)
+	as.Len(comments[0].Replies[0].Replies, 0, "Replies without a loaded parent should be dropped")
+}
+
+func TestCreateCommentReplyToDeleted(t *testing.T) {
+	as := assert.New(t)
+	deletedAt := time.Now()
+	parent := "11111111-1111-1111-1111-111111111111"
+
+	articleStoreMock.On("FindOneBySlug", mock.Anything, "slug").Return(&model.Article{ID: "article", AuthorUsername: "author"}, "v1").Once()
+	blockRepoMock.On("IsBlockedEither", mock.Anything, "username", "author").Return(false, nil).Once()
+	commentRepoMock.On("FindOneByID", mock.Anything, parent).Return(&model.Comment{ID: parent, ArticleID: "article", DeletedAt: &deletedAt}, nil).Once()
+	commentRepoMock.Calls = nil
+	c, err := articleService.CreateComment(tctx, &model.CreateCommentFields{Body: "late", ParentID: &parent}, "username", "slug")
+	commentRepoMock.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+
+	as.Nil(c)
+	if as.NotNil(err) {
+		as.Equal(http.StatusBadRequest, err.Code)
+		as.Equal(ErrReplyDeletedComment, err.Err)
+	}
+}
+
+func TestUpdateCommentAfterEditWindow(t *testing.T) {
+	as := assert.New(t)
+	defer func(w time.Duration) { config.CommentEditWindow = w }(config.CommentEditWindow)
+	config.CommentEditWindow = time.Minute
+	comm := &model.Comment{
+		ID:             "comment",
+		Body:           "old",
+		ArticleID:      "article",
+		AuthorUsername: "username",
+		CreatedAt:      time.Now().Add(-time.Hour),
+	}
+
+	articleStoreMock.On("FindOneBySlug", mock.Anything, "slug").Return(&model.Article{ID: "article"}, "v1").Once()
+	commentRepoMock.On("FindOneByID", mock.Anything, comm.ID).Return(comm, nil).Once()
+	c, err := articleService.UpdateComment(tctx, &model.UpdateCommentFields{Body: "new"}, "username", "slug", comm.ID)
+	commentRepoMock.AssertExpectations(t)
+	commentRepoMock.AssertNotCalled(t, "UpdateOne", mock.Anything, mock.Anything)
+
+	as.Nil(c)
+	as.NotNil(err)
+	as.Equal(http.StatusForbidden, err.Code)
+	as.Equal(ErrCommentEditWindow, err.Err)
+}
+
+func TestGetCommentEditsNotModerator(t *testing.T) {
+	as := assert.New(t)
+
+	userRepoMock.On("FindOneByUsername", mock.Anything, "not-moderator").Return(&model.User{Username: "not-moderator"}, nil).Once()
+	edits, err := articleService.GetCommentEdits(tctx, "not-moderator", "slug", "comment")
+	commentRepoMock.AssertNotCalled(t, "FindEditsByID", mock.Anything, mock.Anything)
+
+	as.Nil(edits)
+	as.NotNil(err)
+	as.Equal(http.StatusForbidden, err.Code)
+	as.Equal(ErrNotModerator, err.Err)
+}
+
+func TestGetCommentEditsOfOtherArticle(t *testing.T) {
+	as := assert.New(t)
+	commentRepoMock.Calls = nil
+
+	userRepoMock.On("FindOneByUsername", mock.Anything, "moderator").Return(&model.User{Username: "moderator", Moderator: true}, nil).Once()
+	articleStoreMock.On("FindOneBySlug", mock.Anything, "slug").Return(&model.Article{ID: "article"}, "v1").Once()
+	commentRepoMock.On("FindOneByID", mock.Anything, "elsewhere").Return(&model.Comment{ID: "elsewhere", ArticleID: "other"}, nil).Once()
+	edits, err := articleService.GetCommentEdits(tctx, "moderator", "slug", "elsewhere")
+	commentRepoMock.AssertNotCalled(t, "FindEditsByID", mock.Anything, mock.Anything)
+
+	as.Nil(edits)
+	as.NotNil(err)
+	as.Equal(http.StatusNotFound, err.Code)
+	as.Equal(ErrNoCommentFound, err.Err)
+}
@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var publicArticle = &model.Article{ID: "article", AuthorUsername: "author", Author: &model.ProfileRs{Username: "author"}}
+
+func TestSaveCommentMentions(t *testing.T) {
+	as := assert.New(t)
+	c := &model.Comment{
+		ID:             "comment",
+		ArticleID:      "article",
+		AuthorUsername: "author",
+		Body:           "@alice @bob @ghost thanks, and @carol too",
+	}
+	found := []string{"alice", "bob", "carol"}
+
+	userRepoMock.On("FindUsernames", mock.Anything, []string{"alice", "bob", "ghost", "carol"}).Return(found, nil).Once()
+	// carol was already mentioned before the edit
+	mentionRepoMock.On("ReplaceCommentMentions", mock.Anything, "article", "comment", found).Return([]string{"alice", "bob"}, nil).Once()
+	blockRepoMock.On("IsBlockedEither", mock.Anything, "author", "alice").Return(false, nil).Once()
+	blockRepoMock.On("IsBlockedEither", mock.Anything, "author", "bob").Return(true, nil).Once()
+	notifyRepoMock.On("InsertOne", mock.Anything, mock.Anything).Return(false, nil).Once()
+	articleService.SaveCommentMentions(tctx, publicArticle, c)
+	mentionRepoMock.AssertExpectations(t)
+	notifyRepoMock.AssertCalled(t, "InsertOne", mock.Anything, &model.CreateNotificationArgs{
+		Recipient: "alice",
+		Actor:     "author",
+		Type:      model.NotificationMention,
+		ArticleID: &c.ArticleID,
+		CommentID: &c.ID,
+	})
+	notifyRepoMock.AssertNotCalled(t, "InsertOne", mock.Anything, mock.MatchedBy(func(n *model.CreateNotificationArgs) bool {
+		return n.Recipient == "bob" || n.Recipient == "carol"
+	}))
+
+	as.Equal([]string{"alice", "bob", "carol"}, []string(c.Mentions))
+}
+
+func TestSaveCommentMentionsFailureIsNotFatal(t *testing.T) {
+	as := assert.New(t)
+	c := &model.Comment{ID: "failing", ArticleID: "article", AuthorUsername: "author", Body: "@dave hello"}
+
+	userRepoMock.On("FindUsernames", mock.Anything, []string{"dave"}).Return([]string{"dave"}, nil).Once()
+	mentionRepoMock.On("ReplaceCommentMentions", mock.Anything, "article", "failing", []string{"dave"}).Return([]string{}, errors.New("connection refused")).Once()
+	notifyRepoMock.Calls = nil
+	articleService.SaveCommentMentions(tctx, publicArticle, c)
+	mentionRepoMock.AssertExpectations(t)
+	notifyRepoMock.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+
+	as.Empty(c.Mentions, "Mentions that were not stored should not be linked")
+}
+
+func TestSaveCommentMentionsOnPrivateArticle(t *testing.T) {
+	as := assert.New(t)
+	a := &model.Article{ID: "private", AuthorUsername: "recluse", Author: &model.ProfileRs{Username: "recluse", Private: true}}
+	c := &model.Comment{ID: "whisper", ArticleID: a.ID, AuthorUsername: "recluse", Body: "@friend @stranger"}
+	found := []string{"friend", "stranger"}
+	friend, none := "recluse", (*string)(nil)
+
+	userRepoMock.On("FindUsernames", mock.Anything, found).Return(found, nil).Once()
+	mentionRepoMock.On("ReplaceCommentMentions", mock.Anything, a.ID, c.ID, found).Return(found, nil).Once()
+	blockRepoMock.On("IsBlockedEither", mock.Anything, "recluse", "friend").Return(false, nil).Once()
+	blockRepoMock.On("IsBlockedEither", mock.Anything, "recluse", "stranger").Return(false, nil).Once()
+	followRepoMock.On("FindOneByIDs", mock.Anything, "friend", "recluse").Return(&friend, nil).Once()
+	followRepoMock.On("FindOneByIDs", mock.Anything, "stranger", "recluse").Return(none, sql.ErrNoRows).Once()
+	notifyRepoMock.On("InsertOne", mock.Anything, mock.Anything).Return(false, nil).Once()
+	notifyRepoMock.Calls = nil
+	articleService.SaveCommentMentions(tctx, a, c)
+	followRepoMock.AssertExpectations(t)
+	notifyRepoMock.AssertNumberOfCalls(t, "InsertOne", 1)
+	notifyRepoMock.AssertCalled(t, "InsertOne", mock.Anything, mock.MatchedBy(func(n *model.CreateNotificationArgs) bool {
+		return n.Recipient == "friend"
+	}))
+
+	as.Equal(found, []string(c.Mentions), "Mentions are still linked, only the notification is held back")
+}
@@ -0,0 +1,46 @@
+package service_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	. "github.com/ashalfarhan/realworld/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReactToArticle(t *testing.T) {
+	defer func(r []string) { config.Reactions = r }(config.Reactions)
+	config.Reactions = []string{"like", "love"}
+
+	t.Run("Should fail with an unsupported reaction", func(t *testing.T) {
+		as := assert.New(t)
+		a, err := articleService.ReactToArticle(tctx, "username", "slug", "nope")
+		reactionRepoMock.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything)
+
+		as.Nil(a)
+		as.NotNil(err)
+		as.Equal(http.StatusUnprocessableEntity, err.Code)
+		as.Equal(ErrInvalidReaction, err.Err)
+	})
+
+	t.Run("Should return the updated reactions", func(t *testing.T) {
+		as := assert.New(t)
+		ar := &model.Article{ID: "article", AuthorUsername: "author"}
+		articleStoreMock.On("FindOneBySlug", mock.Anything, "slug").Return(ar, "v1").Once()
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "username", "author").Return(false, nil).Once()
+		reactionRepoMock.On("InsertOne", mock.Anything, mock.Anything).Return(nil).Once()
+		reactionRepoMock.On("FindByArticleIDs", mock.Anything, []string{"article"}, "username").Return([]*model.ReactionCount{
+			{TargetID: "article", Reaction: "love", Count: 1, Reacted: true},
+		}, nil).Once()
+		a, err := articleService.ReactToArticle(tctx, "username", "slug", "love")
+		reactionRepoMock.AssertExpectations(t)
+		reactionRepoMock.AssertCalled(t, "InsertOne", mock.Anything, &model.Reaction{Username: "username", ArticleID: &ar.ID, Reaction: "love"})
+
+		as.Nil(err)
+		as.Equal(map[string]int{"love": 1}, a.Reactions)
+		as.Equal([]string{"love"}, a.ViewerReactions)
+	})
+}
@@ -1,9 +1,17 @@
 package service_test
 
 import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ashalfarhan/realworld/cache/store"
 	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	. "github.com/ashalfarhan/realworld/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -21,7 +29,18 @@ func TestCreateArticle(t *testing.T) {
 
 	articleTagsRepoMock.On("InsertBulk", mockCtx, mock.Anything).Return(nil)
 	articleRepoMock.On("InsertOne", mockCtx, d, username).Return(&model.Article{}, nil)
+	eventStoreMock.On("Publish", mockCtx, mock.Anything, mock.Anything).Return(nil).Times(5)
+	eventStoreMock.Calls = nil
 	a, err := articleService.CreateArticle(tctx, d, username)
+	eventStoreMock.AssertCalled(t, "Publish", mockCtx, model.AuthorChannel(username), mock.Anything)
+	for _, tag := range d.TagList {
+		eventStoreMock.AssertCalled(t, "Publish", mockCtx, model.TagChannel(tag), mock.Anything)
+	}
+	ids := map[string]bool{}
+	for _, call := range eventStoreMock.Calls {
+		ids[call.Arguments.Get(2).(*model.Event).ID] = true
+	}
+	as.Len(ids, 1, "Every channel should get the same event")
 	articleRepoMock.AssertExpectations(t)
 	userRepoMock.AssertExpectations(t)
 	articleTagsRepoMock.AssertExpectations(t)
@@ -33,3 +52,270 @@ func TestCreateArticle(t *testing.T) {
 		as.Greater(d.Slug, d.Title, "Slug length must be greater than title, and added id")
 	}
 }
+
+func TestCreateArticleOfPrivateAuthor(t *testing.T) {
+	as := assert.New(t)
+	d := &model.CreateArticleFields{Title: "Only for followers", TagList: []string{"secret"}}
+
+	userRepoMock.On("FindOneByUsername", mockCtx, "recluse").Return(&model.User{Username: "recluse", Private: true}, nil).Once()
+	articleRepoMock.On("InsertOne", mockCtx, d, "recluse").Return(&model.Article{AuthorUsername: "recluse"}, nil).Once()
+	eventStoreMock.On("Publish", mockCtx, model.AuthorChannel("recluse"), mock.Anything).Return(nil).Once()
+	eventStoreMock.Calls = nil
+	_, err := articleService.CreateArticle(tctx, d, "recluse")
+	eventStoreMock.AssertCalled(t, "Publish", mockCtx, model.AuthorChannel("recluse"), mock.Anything)
+	eventStoreMock.AssertNotCalled(t, "Publish", mockCtx, model.TagChannel("secret"), mock.Anything)
+
+	as.Nil(err)
+}
+
+func TestGetArticleBySlugRendersBody(t *testing.T) {
+	as := assert.New(t)
+	cached := &model.Article{ID: "article", Body: "Hi **@jake**", Mentions: []string{"jake"}}
+
+	articleStoreMock.On("FindOneBySlug", mockCtx, "rendered").Return(cached, "v1").Once()
+	a, err := articleService.GetArticleBySlug(tctx, "username", "rendered")
+
+	as.Nil(err)
+	as.Equal("<p>Hi <strong><a href=\"/profile/jake\" class=\"mention\" rel=\"nofollow\">@jake</a></strong></p>\n", a.BodyHTML)
+	as.Empty(a.Serialize().BodyHTML, "HTML should only be returned when asked for")
+	as.Equal(a.BodyHTML, a.SerializeFormat(model.FormatHTML).BodyHTML)
+}
+
+func TestDeleteArticleInvalidatesCache(t *testing.T) {
+	as := assert.New(t)
+	ar := &model.Article{ID: "deleted", Slug: "deleted", AuthorUsername: "username"}
+
+	articleRepoMock.On("FindOneBySlug", mockCtx, "username", "deleted").Return(ar, nil).Once()
+	articleTagsRepoMock.On("FindArticleTagsByID", mockCtx, "deleted").Return([]string{}, nil).Once()
+	reactionRepoMock.On("FindByArticleIDs", mockCtx, []string{"deleted"}, "username").Return([]*model.ReactionCount{}, nil).Once()
+	articleRepoMock.On("DeleteBySlug", mockCtx, "deleted").Return(nil).Once()
+	articleStoreMock.Calls = nil
+	err := articleService.DeleteArticle(tctx, "deleted", "username")
+
+	as.Nil(err)
+	articleStoreMock.AssertNotCalled(t, "FindOneBySlug", mock.Anything, mock.Anything)
+	articleStoreMock.AssertCalled(t, "InvalidateBySlug", mockCtx, "deleted")
+}
+
+func TestComputeArticleStats(t *testing.T) {
+	as := assert.New(t)
+	body := "# Title\n\n" + strings.Repeat("word ", 399) + "**end**"
+
+	stats := articleService.ComputeArticleStats(body, "")
+	as.Equal(401, stats.WordCount)
+	as.Equal(3, stats.ReadingTimeMinutes, "Reading time should round up")
+	as.True(strings.HasPrefix(stats.Excerpt, "Title word word"), "Excerpt should be plain text")
+	as.LessOrEqual(len([]rune(stats.Excerpt)), 201)
+
+	stats = articleService.ComputeArticleStats(body, "Written by hand")
+	as.Equal("Written by hand", stats.Excerpt, "Description should be the excerpt")
+
+	as.Equal(model.ArticleStats{}, articleService.ComputeArticleStats("", ""))
+}
+
+func TestGetArticleBySlugCachesShared(t *testing.T) {
+	as := assert.New(t)
+	ar := &model.Article{ID: "uncached", AuthorUsername: "author", Favorited: true}
+	reactions := []*model.Reaction{}
+
+	articleStoreMock.On("FindOneBySlug", mockCtx, "uncached").Return((*model.Article)(nil), "v1").Once()
+	articleRepoMock.On("FindOneBySlug", mockCtx, "viewer", "uncached").Return(ar, nil).Once()
+	articleTagsRepoMock.On("FindArticleTagsByID", mockCtx, "uncached").Return([]string{}, nil).Once()
+	reactionRepoMock.On("FindByArticleIDs", mockCtx, []string{"uncached"}, "viewer").Return([]*model.ReactionCount{}, nil).Once()
+	articleStoreMock.On("SaveBySlug", mockCtx, "uncached", "v1", ar).Once()
+	viewerStoreMock.On("Exists", mockCtx, "viewer").Return(false).Once()
+	favoritesRepoMock.On("FindArticleIDsByUsername", mockCtx, "viewer").Return([]string{"uncached"}, nil).Once()
+	bookmarkRepoMock.On("FindArticleIDsByUsername", mockCtx, "viewer").Return([]string{}, nil).Once()
+	followRepoMock.On("FindAllFollowingUsernames", mockCtx, "viewer").Return([]string{"author"}, nil).Once()
+	reactionRepoMock.On("FindArticleReactionsByUsername", mockCtx, "viewer").Return(reactions, nil).Once()
+	viewerStoreMock.On("Save", mockCtx, "viewer", mock.Anything).Once()
+
+	a, err := articleService.GetArticleBySlug(tctx, "viewer", "uncached")
+	as.Nil(err)
+	as.True(a.Favorited)
+	articleStoreMock.AssertCalled(t, "SaveBySlug", mockCtx, "uncached", "v1", ar)
+	viewerStoreMock.AssertCalled(t, "Save", mockCtx, "viewer", &store.ViewerState{
+		Favorites: []string{"uncached"},
+		Bookmarks: []string{},
+		Following: []string{"author"},
+		Reactions: reactions,
+	})
+}
+
+func TestUnfavoriteArticleCounter(t *testing.T) {
+	for _, deleted := range []int64{1, 0} {
+		as := assert.New(t)
+		articleStoreMock.Calls = nil
+		// The viewer overlay says favorited either way, it may be stale
+		cached := &model.Article{ID: "article", FavoritesCount: 3, Favorited: true}
+
+		articleStoreMock.On("FindOneBySlug", mockCtx, "favorite").Return(cached, "v1").Once()
+		favoritesRepoMock.On("Delete", mockCtx, "username", "article").Return(deleted, nil).Once()
+		a, err := articleService.UnfavoriteArticleBySlug(tctx, "username", "favorite")
+
+		as.Nil(err)
+		as.False(a.Favorited)
+		viewerStoreMock.AssertCalled(t, "SetFavorited", mockCtx, "username", "article", false)
+		if deleted == 1 {
+			as.Equal(2, a.FavoritesCount)
+			articleStoreMock.AssertCalled(t, "IncrFavoritesCount", mockCtx, "favorite", int64(-1))
+		} else {
+			as.Equal(3, a.FavoritesCount, "Nothing was unfavorited")
+			articleStoreMock.AssertNotCalled(t, "IncrFavoritesCount", mockCtx, "favorite", mock.Anything)
+		}
+	}
+}
+
+func TestGetArticlesCached(t *testing.T) {
+	as := assert.New(t)
+	articleRepoMock.Calls = nil
+	args := &model.FindArticlesArgs{Username: "cached", Limit: 10}
+	cached := model.Articles{{ID: "article"}}
+
+	listStoreMock.On("Find", mockCtx, args).Return(cached).Once()
+	articles, err := articleService.GetArticles(tctx, args)
+
+	as.Nil(err)
+	as.Equal(cached, articles)
+	articleRepoMock.AssertNotCalled(t, "Find", mockCtx, args)
+}
+
+func TestGetArticlesReactionsInOneQuery(t *testing.T) {
+	as := assert.New(t)
+	reactionRepoMock.Calls = nil
+	args := &model.FindArticlesArgs{Username: "reader", Limit: 10}
+	found := model.Articles{{ID: "first"}, {ID: "second"}}
+
+	listStoreMock.On("Find", mockCtx, args).Return(model.Articles(nil)).Once()
+	listStoreMock.On("Save", mockCtx, args, found).Once()
+	articleRepoMock.On("Find", mockCtx, args).Return(found, nil).Once()
+	articleTagsRepoMock.On("FindArticleTagsByID", mockCtx, "first").Return([]string{}, nil).Once()
+	articleTagsRepoMock.On("FindArticleTagsByID", mockCtx, "second").Return([]string{}, nil).Once()
+	reactionRepoMock.On("FindByArticleIDs", mockCtx, []string{"first", "second"}, "reader").Return([]*model.ReactionCount{
+		{TargetID: "second", Reaction: "love", Count: 2, Reacted: true},
+	}, nil).Once()
+	articles, err := articleService.GetArticles(tctx, args)
+
+	as.Nil(err)
+	as.Len(articles, 2)
+	as.Empty(articles[0].Reactions)
+	as.Equal(2, articles[1].Reactions["love"])
+	as.Equal([]string{"love"}, articles[1].ViewerReactions)
+	reactionRepoMock.AssertNumberOfCalls(t, "FindByArticleIDs", 1)
+}
+
+func TestGetArticlesFeedSingleLoad(t *testing.T) {
+	as := assert.New(t)
+	articleRepoMock.Calls, listStoreMock.Calls = nil, nil
+	args := &model.FindArticlesArgs{Username: "herd", Feed: true, Limit: 10}
+	release := make(chan struct{})
+
+	listStoreMock.On("Find", mockCtx, args).Return(model.Articles(nil)).Times(5)
+	listStoreMock.On("Save", mockCtx, args, model.Articles{}).Once()
+	articleRepoMock.On("Find", mockCtx, args).Run(func(mock.Arguments) { <-release }).Return(model.Articles{}, nil).Once()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			articles, err := articleService.GetArticlesFeed(tctx, args)
+			as.Nil(err)
+			as.NotNil(articles)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	articleRepoMock.AssertNumberOfCalls(t, "Find", 1)
+	listStoreMock.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestGetArticlesSharedLoadOutlivesCaller(t *testing.T) {
+	as := assert.New(t)
+	args := &model.FindArticlesArgs{Username: "leaver", Feed: true, Limit: 10}
+	release := make(chan struct{})
+
+	listStoreMock.On("Find", mock.Anything, args).Return(model.Articles(nil)).Times(2)
+	listStoreMock.On("Save", mock.Anything, args, model.Articles{}).Once()
+	articleRepoMock.On("Find", mock.Anything, args).Run(func(mock.Arguments) { <-release }).Return(model.Articles{}, nil).Once()
+
+	// The first caller starts the load and leaves before it is done
+	ctx, cancel := context.WithCancel(tctx)
+	leaver := make(chan *model.ConduitError)
+	go func() {
+		_, err := articleService.GetArticlesFeed(ctx, args)
+		leaver <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	waiter := make(chan model.Articles)
+	go func() {
+		articles, err := articleService.GetArticlesFeed(tctx, args)
+		as.Nil(err)
+		waiter <- articles
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	as.NotNil(<-leaver, "The caller that left should stop waiting")
+	close(release)
+
+	as.NotNil(<-waiter, "The others should still get the result")
+}
+
+func TestUpdateArticleVersionConflict(t *testing.T) {
+	stale := 1
+	testCases := []struct {
+		desc     string
+		fields   *model.UpdateArticleFields
+		repoErr  error
+		errCode  int
+		errError error
+	}{
+		{
+			desc:     "If-Match without the current version should fail its precondition",
+			fields:   &model.UpdateArticleFields{IfMatch: []int{1}},
+			errCode:  http.StatusPreconditionFailed,
+			errError: ErrPreconditionFailed,
+		},
+		{
+			desc:     "A stale version in the body should conflict",
+			fields:   &model.UpdateArticleFields{Version: &stale},
+			errCode:  http.StatusConflict,
+			errError: ErrVersionConflict,
+		},
+		{
+			desc:     "An update in between reading and writing should conflict",
+			fields:   &model.UpdateArticleFields{},
+			repoErr:  repository.ErrStaleVersion,
+			errCode:  http.StatusConflict,
+			errError: ErrVersionConflict,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			as := assert.New(t)
+			ar := &model.Article{ID: "edited", Slug: "edited", AuthorUsername: "username", Version: 2}
+
+			articleRepoMock.On("FindOneBySlug", mockCtx, "username", "edited").Return(ar, nil).Once()
+			articleTagsRepoMock.On("FindArticleTagsByID", mockCtx, "edited").Return([]string{}, nil).Once()
+			reactionRepoMock.On("FindByArticleIDs", mockCtx, []string{"edited"}, "username").Return([]*model.ReactionCount{}, nil).Once()
+			if tC.repoErr != nil {
+				articleRepoMock.On("UpdateOneBySlug", mockCtx, tC.fields, mock.Anything, mock.Anything).Return(tC.repoErr).Once()
+			}
+			articleRepoMock.Calls = nil
+			articleStoreMock.Calls = nil
+			res, err := articleService.UpdateArticleBySlug(tctx, "username", "edited", tC.fields)
+			if tC.repoErr == nil {
+				articleRepoMock.AssertNotCalled(t, "UpdateOneBySlug", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			articleStoreMock.AssertNotCalled(t, "FindOneBySlug", mock.Anything, mock.Anything)
+
+			as.Nil(res)
+			if as.NotNil(err) {
+				as.Equal(tC.errCode, err.Code)
+				as.Equal(tC.errError, err.Err)
+			}
+		})
+	}
+}
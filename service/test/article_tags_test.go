@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	. "github.com/ashalfarhan/realworld/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiffArticleTags(t *testing.T) {
+	as := assert.New(t)
+	a := &model.Article{ID: "article-id", TagList: []string{"golang", "react"}}
+
+	diff := DiffArticleTags(a, []string{"golang", "typescript"})
+
+	as.Equal([]repository.InsertArticleTagsArgs{{ArticleID: a.ID, TagName: "typescript"}}, diff.Added)
+	as.Equal([]string{"react"}, diff.Removed)
+}
+
+func TestUpdateArticleTagsWithArticle(t *testing.T) {
+	as := assert.New(t)
+	tagList := []string{"golang", "typescript"}
+	fields := &model.UpdateArticleFields{TagList: &tagList}
+	ar := &model.Article{ID: "retagged", Slug: "retagged", AuthorUsername: "username", TagList: []string{"golang", "react"}}
+
+	articleRepoMock.On("FindOneBySlug", mockCtx, "username", "retagged").Return(ar, nil).Once()
+	articleTagsRepoMock.On("FindArticleTagsByID", mockCtx, "retagged").Return([]string{"golang", "react"}, nil).Once()
+	reactionRepoMock.On("FindByArticleIDs", mockCtx, []string{"retagged"}, "username").Return([]*model.ReactionCount{}, nil).Once()
+	articleRepoMock.On("UpdateOneBySlug", mockCtx, fields, ar, &repository.ArticleTagsDiff{
+		Added:   []repository.InsertArticleTagsArgs{{ArticleID: "retagged", TagName: "typescript"}},
+		Removed: []string{"react"},
+	}).Return(nil).Once()
+	articleTagsRepoMock.Calls = nil
+	res, err := articleService.UpdateArticleBySlug(tctx, "username", "retagged", fields)
+	articleRepoMock.AssertExpectations(t)
+	articleTagsRepoMock.AssertNotCalled(t, "InsertBulk", mock.Anything, mock.Anything)
+
+	as.Nil(err)
+	as.Equal(tagList, res.TagList, "Tag list should be replaced")
+}
+
+func TestGetTagNotFound(t *testing.T) {
+	as := assert.New(t)
+
+	tagRepoMock.On("FindOneByName", mockCtx, "", "unknown").Return(&model.Tag{}, sql.ErrNoRows).Once()
+	tag, err := articleService.GetTag(tctx, "", "unknown")
+	tagRepoMock.AssertExpectations(t)
+
+	as.Nil(tag)
+	if as.NotNil(err) {
+		as.Equal(http.StatusNotFound, err.Code)
+		as.Equal(ErrNoTagFound, err.Err)
+	}
+}
+
+func TestFollowTagFailIfAlreadyFollow(t *testing.T) {
+	as := assert.New(t)
+
+	tagRepoMock.On("FindOneByName", mockCtx, "username", "golang").Return(&model.Tag{Name: "golang"}, nil).Once()
+	tagFollowRepoMock.On("InsertOne", mockCtx, "username", "golang").Return(errors.New(repository.ErrDuplicateTagFollow)).Once()
+	tag, err := articleService.FollowTag(tctx, "username", "golang")
+	tagRepoMock.AssertExpectations(t)
+	tagFollowRepoMock.AssertExpectations(t)
+
+	as.Nil(tag)
+	if as.NotNil(err) {
+		as.Equal(http.StatusBadRequest, err.Code)
+		as.Equal(ErrAlreadyFollowTag, err.Err)
+	}
+}
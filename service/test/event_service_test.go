@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEventIsVisible(t *testing.T) {
+	t.Run("Own events should be visible", func(t *testing.T) {
+		as := assert.New(t)
+		as.True(eventService.IsVisible(tctx, "username", &model.Event{Actor: "username"}))
+		blockRepoMock.AssertNotCalled(t, "IsBlockedEither", mock.Anything, "username", "username")
+	})
+
+	t.Run("Events of blocked users should be hidden", func(t *testing.T) {
+		as := assert.New(t)
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "viewer", "blocked").Return(true, nil).Once()
+		as.False(eventService.IsVisible(tctx, "viewer", &model.Event{Actor: "blocked"}))
+	})
+
+	t.Run("Events of muted users should be hidden", func(t *testing.T) {
+		as := assert.New(t)
+		muted := "muted"
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "viewer", muted).Return(false, nil).Once()
+		muteRepoMock.On("FindOneByIDs", mock.Anything, "viewer", muted).Return(&muted, nil).Once()
+		as.False(eventService.IsVisible(tctx, "viewer", &model.Event{Actor: muted}))
+	})
+
+	t.Run("Events of other users should be visible", func(t *testing.T) {
+		as := assert.New(t)
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "viewer", "other").Return(false, nil).Once()
+		muteRepoMock.On("FindOneByIDs", mock.Anything, "viewer", "other").Return((*string)(nil), sql.ErrNoRows).Once()
+		as.True(eventService.IsVisible(tctx, "viewer", &model.Event{Actor: "other"}))
+	})
+
+	t.Run("Events should be dropped when blocks cannot be checked", func(t *testing.T) {
+		as := assert.New(t)
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "viewer", "unchecked").Return(false, errors.New("connection refused")).Once()
+		as.False(eventService.IsVisible(tctx, "viewer", &model.Event{Actor: "unchecked"}))
+	})
+}
+
+func TestEventSubscribe(t *testing.T) {
+	as := assert.New(t)
+	events := make(<-chan *model.Event)
+	followRepoMock.On("FindFollowingUsernames", mock.Anything, "viewer").Return([]string{"author"}, nil).Once()
+	tagFollowRepoMock.On("FindTagNames", mock.Anything, "viewer").Return([]string{"golang"}, nil).Once()
+	eventStoreMock.On("Subscribe", mock.Anything).Return(events, func() {}).Once()
+	ch, cancel, err := eventService.Subscribe(tctx, "viewer", "article-id")
+	eventStoreMock.AssertCalled(t, "Subscribe", []string{
+		model.UserChannel("viewer"),
+		model.AuthorChannel("author"),
+		model.TagChannel("golang"),
+		model.ArticleChannel("article-id"),
+	})
+
+	as.Nil(err)
+	as.NotNil(cancel)
+	as.Equal(events, ch)
+
+	// Followed since
+	followRepoMock.On("FindFollowingUsernames", mock.Anything, "viewer").Return([]string{"author", "other"}, nil).Once()
+	tagFollowRepoMock.On("FindTagNames", mock.Anything, "viewer").Return([]string{}, nil).Once()
+	eventStoreMock.On("Resubscribe", events, mock.Anything).Once()
+	eventService.Resubscribe(tctx, events, "viewer", "article-id")
+	eventStoreMock.AssertCalled(t, "Resubscribe", events, []string{
+		model.UserChannel("viewer"),
+		model.AuthorChannel("author"),
+		model.AuthorChannel("other"),
+		model.ArticleChannel("article-id"),
+	})
+}
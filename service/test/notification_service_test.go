@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFollowUserNotifies(t *testing.T) {
+	as := assert.New(t)
+
+	userRepoMock.On("FindOne", mock.Anything, mock.Anything).Return(&model.User{Username: "followed"}, nil).Once()
+	blockRepoMock.On("IsBlockedEither", mock.Anything, "follower", "followed").Return(false, nil).Once()
+	followRepoMock.On("InsertOne", mock.Anything, "follower", "followed").Return(nil).Once()
+	notifyRepoMock.On("InsertOne", mock.Anything, mock.Anything).Return(true, nil).Once()
+	eventStoreMock.On("Publish", mock.Anything, model.UserChannel("followed"), mock.Anything).Return(nil).Once()
+	u, err := userService.FollowUser(tctx, "follower", "followed")
+	notifyRepoMock.AssertCalled(t, "InsertOne", mock.Anything, &model.CreateNotificationArgs{
+		Recipient: "followed",
+		Actor:     "follower",
+		Type:      model.NotificationFollow,
+	})
+	// TestFollowUser asserts on the follow calls
+	followRepoMock.Calls = nil
+
+	as.Nil(err)
+	as.True(u.Following)
+}
+
+func TestGetNotifications(t *testing.T) {
+	as := assert.New(t)
+	args := &model.FindNotificationsArgs{Username: "username", Limit: 20}
+	notifications := []*model.Notification{
+		{ID: "n1", Type: model.NotificationFavorite, Actors: pq.StringArray{"a", "b", "a", "c", "d"}, ActorsCount: 4},
+	}
+
+	notifyRepoMock.On("Find", mock.Anything, args).Return(notifications, nil).Once()
+	notifyRepoMock.On("CountUnread", mock.Anything, args.Username).Return(1, nil).Once()
+	res, unread, err := notificationService.GetNotifications(tctx, args)
+	notifyRepoMock.AssertExpectations(t)
+
+	as.Nil(err)
+	as.Equal(1, unread)
+	as.Equal(pq.StringArray{"a", "b", "c"}, res[0].Actors, "Only the latest distinct actors should be listed")
+	as.Equal(4, res[0].ActorsCount)
+}
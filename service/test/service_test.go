@@ -11,6 +11,7 @@ import (
 	"github.com/ashalfarhan/realworld/persistence/repository"
 	repoMocks "github.com/ashalfarhan/realworld/persistence/repository/mocks"
 	. "github.com/ashalfarhan/realworld/service"
+	blobMocks "github.com/ashalfarhan/realworld/storage/mocks"
 	"github.com/ashalfarhan/realworld/utils/logger"
 	"github.com/stretchr/testify/mock"
 )
@@ -20,13 +21,32 @@ var (
 	articleRepoMock     *repoMocks.ArticleRepoMock
 	followRepoMock      *repoMocks.FollowingRepoMock
 	articleTagsRepoMock *repoMocks.ArticleTagsRepoMock
+	tagRepoMock         *repoMocks.TagRepoMock
+	tagFollowRepoMock   *repoMocks.TagFollowingRepoMock
+	blockRepoMock       *repoMocks.BlockingRepoMock
+	muteRepoMock        *repoMocks.MutingRepoMock
+	requestRepoMock     *repoMocks.FollowRequestRepoMock
+	commentRepoMock     *repoMocks.CommentRepoMock
+	reactionRepoMock    *repoMocks.ReactionRepoMock
+	bookmarkRepoMock    *repoMocks.BookmarkRepoMock
+	notifyRepoMock      *repoMocks.NotificationRepoMock
+	mentionRepoMock     *repoMocks.MentionRepoMock
+	uploadRepoMock      *repoMocks.UploadRepoMock
+	favoritesRepoMock   *repoMocks.ArticleFavoritesRepoMock
 	repo                *repository.Repository
 
 	articleStoreMock *storeMocks.ArticleStoreMock
+	viewerStoreMock  *storeMocks.ViewerStoreMock
+	listStoreMock    *storeMocks.ListStoreMock
+	eventStoreMock   *storeMocks.EventStoreMock
 	cacheStore       *store.CacheStore
+	blobStoreMock    *blobMocks.BlobStoreMock
 
-	userService    *UserService
-	articleService *ArticleService
+	userService         *UserService
+	articleService      *ArticleService
+	notificationService *NotificationService
+	eventService        *EventService
+	uploadService       *UploadService
 
 	tctx    = context.TODO()
 	mockCtx = mock.Anything
@@ -46,18 +66,62 @@ func setup() {
 	articleRepoMock = new(repoMocks.ArticleRepoMock)
 	followRepoMock = new(repoMocks.FollowingRepoMock)
 	articleTagsRepoMock = new(repoMocks.ArticleTagsRepoMock)
+	tagRepoMock = new(repoMocks.TagRepoMock)
+	tagFollowRepoMock = new(repoMocks.TagFollowingRepoMock)
+	blockRepoMock = new(repoMocks.BlockingRepoMock)
+	muteRepoMock = new(repoMocks.MutingRepoMock)
+	requestRepoMock = new(repoMocks.FollowRequestRepoMock)
+	commentRepoMock = new(repoMocks.CommentRepoMock)
+	reactionRepoMock = new(repoMocks.ReactionRepoMock)
+	bookmarkRepoMock = new(repoMocks.BookmarkRepoMock)
+	notifyRepoMock = new(repoMocks.NotificationRepoMock)
+	mentionRepoMock = new(repoMocks.MentionRepoMock)
+	uploadRepoMock = new(repoMocks.UploadRepoMock)
+	favoritesRepoMock = new(repoMocks.ArticleFavoritesRepoMock)
 	repo = &repository.Repository{
-		UserRepo:        userRepoMock,
-		ArticleRepo:     articleRepoMock,
-		FollowRepo:      followRepoMock,
-		ArticleTagsRepo: articleTagsRepoMock,
+		UserRepo:             userRepoMock,
+		ArticleRepo:          articleRepoMock,
+		FollowRepo:           followRepoMock,
+		ArticleTagsRepo:      articleTagsRepoMock,
+		TagRepo:              tagRepoMock,
+		TagFollowRepo:        tagFollowRepoMock,
+		BlockRepo:            blockRepoMock,
+		MuteRepo:             muteRepoMock,
+		FollowRequestRepo:    requestRepoMock,
+		CommentRepo:          commentRepoMock,
+		ReactionRepo:         reactionRepoMock,
+		BookmarkRepo:         bookmarkRepoMock,
+		NotificationRepo:     notifyRepoMock,
+		MentionRepo:          mentionRepoMock,
+		UploadRepo:           uploadRepoMock,
+		ArticleFavoritesRepo: favoritesRepoMock,
 	}
 
 	articleStoreMock = new(storeMocks.ArticleStoreMock)
+	articleStoreMock.On("InvalidateBySlug", mock.Anything, mock.Anything)
+	articleStoreMock.On("IncrFavoritesCount", mock.Anything, mock.Anything, mock.Anything)
+	viewerStoreMock = new(storeMocks.ViewerStoreMock)
+	viewerStoreMock.On("Overlay", mock.Anything, mock.Anything, mock.Anything).Return(true)
+	for _, method := range []string{"SetFavorited", "SetBookmarked", "SetFollowing"} {
+		viewerStoreMock.On(method, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+	viewerStoreMock.On("SetReacted", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	listStoreMock = new(storeMocks.ListStoreMock)
+	listStoreMock.On("Invalidate", mock.Anything)
+	listStoreMock.On("InvalidateUser", mock.Anything, mock.Anything)
+	eventStoreMock = new(storeMocks.EventStoreMock)
 	cacheStore = &store.CacheStore{
 		ArticleStore: articleStoreMock,
+		ViewerStore:  viewerStoreMock,
+		ListStore:    listStoreMock,
+		EventStore:   eventStoreMock,
 	}
 
-	userService = NewUserService(repo)
-	articleService = NewArticleService(repo, cacheStore)
+	eventService = NewEventService(repo, cacheStore)
+	notificationService = NewNotificationService(repo, eventService)
+	userService = NewUserService(repo, cacheStore, notificationService)
+	articleService = NewArticleService(repo, cacheStore, notificationService, eventService)
+
+	blobStoreMock = new(blobMocks.BlobStoreMock)
+	uploadService = NewUploadService(repo, blobStoreMock)
 }
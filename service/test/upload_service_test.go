@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	. "github.com/ashalfarhan/realworld/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func encodePNG(w, h int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h)))
+	return buf.Bytes()
+}
+
+func TestUploadAvatar(t *testing.T) {
+	as := assert.New(t)
+	defer func(size int64, dim int) {
+		config.UploadMaxSize, config.UploadMaxDimension = size, dim
+	}(config.UploadMaxSize, config.UploadMaxDimension)
+	config.UploadMaxSize, config.UploadMaxDimension = 1<<20, 1000
+	var thumb image.Image
+
+	blobStoreMock.On("Put", mock.Anything, mock.MatchedBy(func(k string) bool {
+		return strings.HasPrefix(k, "avatars/") && !strings.Contains(k, "_thumb")
+	}), mock.Anything, mock.Anything, "image/png").Return(nil).Once()
+	blobStoreMock.On("Put", mock.Anything, mock.MatchedBy(func(k string) bool {
+		return strings.HasSuffix(k, "_thumb.png")
+	}), mock.Anything, mock.Anything, "image/png").Run(func(args mock.Arguments) {
+		thumb, _ = png.Decode(args.Get(2).(io.Reader))
+	}).Return(nil).Once()
+	uploadRepoMock.On("InsertOne", mock.Anything, mock.Anything).Return(nil).Once()
+	blobStoreMock.On("URL", mock.Anything).Return("http://localhost/uploads/key").Twice()
+	u, err := uploadService.Upload(tctx, "username", &model.CreateUploadFields{Purpose: model.UploadAvatar}, bytes.NewReader(encodePNG(300, 200)))
+	blobStoreMock.AssertExpectations(t)
+	uploadRepoMock.AssertExpectations(t)
+
+	as.Nil(err)
+	if as.NotNil(u) {
+		as.Equal("username", u.Username)
+		as.Equal("image/png", u.ContentType)
+		as.Equal(300, u.Width)
+		as.Equal(200, u.Height)
+		as.NotNil(u.ThumbnailURL)
+	}
+	if as.NotNil(thumb) {
+		as.Equal(image.Rect(0, 0, 128, 128), thumb.Bounds(), "Thumbnail should be a cropped square")
+	}
+}
+
+func TestUploadRejected(t *testing.T) {
+	defer func(size int64, dim int) {
+		config.UploadMaxSize, config.UploadMaxDimension = size, dim
+	}(config.UploadMaxSize, config.UploadMaxDimension)
+	config.UploadMaxSize, config.UploadMaxDimension = 1<<20, 100
+	article := &model.CreateUploadFields{Purpose: model.UploadArticle}
+	blobStoreMock.Calls = nil
+
+	cases := map[string]struct {
+		file []byte
+		code int
+		err  error
+	}{
+		"Not an image":     {[]byte("<html><script>alert(1)</script></html>"), http.StatusUnsupportedMediaType, ErrUnsupportedUpload},
+		"Too large":        {make([]byte, config.UploadMaxSize+1), http.StatusRequestEntityTooLarge, ErrUploadTooLarge},
+		"Too many pixels":  {encodePNG(101, 10), http.StatusUnprocessableEntity, ErrUploadDimensions},
+		"Corrupted header": {append([]byte("\x89PNG\r\n\x1a\n"), 0, 0), http.StatusUnsupportedMediaType, ErrUnsupportedUpload},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			as := assert.New(t)
+			u, err := uploadService.Upload(tctx, "username", article, bytes.NewReader(c.file))
+			blobStoreMock.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+			as.Nil(u)
+			if as.NotNil(err) {
+				as.Equal(c.code, err.Code)
+				as.Equal(c.err, err.Err)
+			}
+		})
+	}
+}
+
+func TestDeleteUploadNotOwner(t *testing.T) {
+	as := assert.New(t)
+
+	uploadRepoMock.On("FindOneByID", mock.Anything, "upload").Return(&model.Upload{ID: "upload", Username: "owner"}, nil).Once()
+	err := uploadService.DeleteUpload(tctx, "username", "upload")
+	uploadRepoMock.AssertNotCalled(t, "DeleteOne", mock.Anything, "upload")
+
+	if as.NotNil(err) {
+		as.Equal(http.StatusForbidden, err.Code)
+		as.Equal(ErrNotAllowedDeleteUpload, err.Err)
+	}
+}
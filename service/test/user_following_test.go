@@ -20,6 +20,7 @@ var tests = TestMap{
 		as := assert.New(t)
 
 		userRepoMock.On("FindOne", mock.Anything, mock.Anything).Return(&model.User{}, nil).Once()
+		blockRepoMock.On("IsBlockedEither", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
 		followRepoMock.On("InsertOne", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(repository.ErrDuplicateFollowing)).Once()
 		u, err := userService.FollowUser(tctx, "username", "username2")
 		userRepoMock.AssertExpectations(t)
@@ -45,6 +46,54 @@ var tests = TestMap{
 		as.Equal(err.Code, http.StatusBadRequest)
 		as.Equal(err.Err, ErrSelfFollow)
 	},
+	"Follow user should fail if blocked": func(t *testing.T) {
+		as := assert.New(t)
+
+		userRepoMock.On("FindOne", mock.Anything, mock.Anything).Return(&model.User{Username: "username2"}, nil).Once()
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "username", "username2").Return(true, nil).Once()
+		u, err := userService.FollowUser(tctx, "username", "username2")
+		userRepoMock.AssertExpectations(t)
+		blockRepoMock.AssertExpectations(t)
+		followRepoMock.AssertNotCalled(t, "InsertOne", mock.Anything)
+
+		as.Nil(u)
+		as.NotNil(err)
+		as.Equal(err.Code, http.StatusForbidden)
+		as.Equal(err.Err, ErrBlocked)
+	},
+	"Follow user should fail if blocks cannot be checked": func(t *testing.T) {
+		as := assert.New(t)
+
+		userRepoMock.On("FindOne", mock.Anything, mock.Anything).Return(&model.User{Username: "unchecked"}, nil).Once()
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "username", "unchecked").Return(false, errors.New("connection refused")).Once()
+		followRepoMock.Calls = nil
+		u, err := userService.FollowUser(tctx, "username", "unchecked")
+		blockRepoMock.AssertExpectations(t)
+		followRepoMock.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything, mock.Anything)
+
+		as.Nil(u)
+		if as.NotNil(err) {
+			as.Equal(http.StatusInternalServerError, err.Code)
+		}
+	},
+	"Follow private user should create a follow request": func(t *testing.T) {
+		as := assert.New(t)
+		var none *string
+
+		userRepoMock.On("FindOne", mock.Anything, mock.Anything).Return(&model.User{Username: "username2", Private: true}, nil).Once()
+		blockRepoMock.On("IsBlockedEither", mock.Anything, "username", "username2").Return(false, nil).Once()
+		followRepoMock.On("FindOneByIDs", mock.Anything, "username", "username2").Return(none, sql.ErrNoRows).Once()
+		requestRepoMock.On("InsertOne", mock.Anything, "username", "username2").Return(nil).Once()
+		u, err := userService.FollowUser(tctx, "username", "username2")
+		userRepoMock.AssertExpectations(t)
+		requestRepoMock.AssertExpectations(t)
+		followRepoMock.AssertNotCalled(t, "InsertOne", mock.Anything, mock.Anything, mock.Anything)
+
+		as.Nil(err)
+		as.NotNil(u)
+		as.False(u.Following)
+		as.True(u.FollowRequested)
+	},
 	"Follow user should fail if not found": func(t *testing.T) {
 		as := assert.New(t)
 
@@ -68,5 +117,43 @@ func TestFollowUser(t *testing.T) {
 		// like `afterEach` in jest
 		followRepoMock.Calls = nil
 		userRepoMock.Calls = nil
+		blockRepoMock.Calls = nil
+		requestRepoMock.Calls = nil
 	}
 }
+
+func TestGetFollowers(t *testing.T) {
+	as := assert.New(t)
+	args := &model.FindFollowsArgs{Username: "username", Viewer: "viewer", Limit: 20}
+	followers := []*model.ProfileRs{{Username: "follower", Following: true}}
+	var none *string
+
+	userRepoMock.On("FindOneByUsername", mock.Anything, args.Username).Return(&model.User{Username: args.Username}, nil).Once()
+	followRepoMock.On("FindOneByIDs", mock.Anything, args.Viewer, args.Username).Return(none, sql.ErrNoRows).Once()
+	followRepoMock.On("CountByUsername", mock.Anything, args.Username).Return(3, 1, nil).Once()
+	blockRepoMock.On("FindOneByIDs", mock.Anything, args.Viewer, args.Username).Return(none, sql.ErrNoRows).Once()
+	muteRepoMock.On("FindOneByIDs", mock.Anything, args.Viewer, args.Username).Return(none, sql.ErrNoRows).Once()
+	followRepoMock.On("FindFollowers", mock.Anything, args).Return(followers, nil).Once()
+	profiles, count, err := userService.GetFollowers(tctx, args)
+	userRepoMock.AssertExpectations(t)
+	followRepoMock.AssertExpectations(t)
+
+	as.Nil(err)
+	as.Equal(followers, profiles)
+	as.Equal(3, count, "Count should be the total followers, not the page size")
+}
+
+func TestGetIncomingFollowRequests(t *testing.T) {
+	as := assert.New(t)
+	args := &model.FindFollowsArgs{Username: "private", Viewer: "private", Limit: 1}
+	requesters := []*model.ProfileRs{{Username: "requester"}}
+
+	requestRepoMock.On("FindIncoming", mock.Anything, args).Return(requesters, nil).Once()
+	requestRepoMock.On("CountByUsername", mock.Anything, args.Username).Return(4, 2, nil).Once()
+	profiles, count, err := userService.GetIncomingFollowRequests(tctx, args)
+	requestRepoMock.AssertExpectations(t)
+
+	as.Nil(err)
+	as.Equal(requesters, profiles)
+	as.Equal(4, count, "Count should be the total incoming requests, not the page size")
+}
@@ -61,6 +61,12 @@ func TestUpdateFail(t *testing.T) {
 			errCode:    http.StatusBadRequest,
 			errError:   ErrEmailExist,
 		},
+		{
+			desc:       "Update should fail if the user was updated in the meantime",
+			mockReturn: repository.ErrStaleVersion,
+			errCode:    http.StatusConflict,
+			errError:   ErrVersionConflict,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -79,3 +85,41 @@ func TestUpdateFail(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateVersionMismatch(t *testing.T) {
+	as := assert.New(t)
+	other := 1
+
+	userRepoMock.On("FindOneByUsername", mock.Anything, "versioned").Return(&model.User{}, nil)
+	userRepoMock.Calls = nil
+	d, err := userService.Update(tctx, &model.UpdateUserFields{IfMatch: []int{1, 3}}, "versioned")
+	as.Nil(d)
+	if as.NotNil(err) {
+		as.Equal(http.StatusPreconditionFailed, err.Code)
+		as.Equal(ErrPreconditionFailed, err.Err)
+	}
+
+	d, err = userService.Update(tctx, &model.UpdateUserFields{IfMatch: []int{0}, Version: &other}, "versioned")
+	as.Nil(d)
+	if as.NotNil(err) {
+		as.Equal(http.StatusConflict, err.Code)
+		as.Equal(ErrVersionConflict, err.Err)
+	}
+	userRepoMock.AssertNotCalled(t, "UpdateOne", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateInvalidatesAuthorArticles(t *testing.T) {
+	as := assert.New(t)
+	private := true
+
+	userRepoMock.On("FindOneByUsername", mock.Anything, "author").Return(&model.User{Username: "author"}, nil).Once()
+	userRepoMock.On("UpdateOne", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	articleRepoMock.On("FindSlugsByAuthor", mock.Anything, mock.Anything).Return([]string{"first", "second"}, nil).Once()
+	articleStoreMock.Calls = nil
+	u, err := userService.Update(tctx, &model.UpdateUserFields{Private: &private}, "author")
+
+	as.Nil(err)
+	as.NotNil(u)
+	articleStoreMock.AssertCalled(t, "InvalidateBySlug", mock.Anything, "first")
+	articleStoreMock.AssertCalled(t, "InvalidateBySlug", mock.Anything, "second")
+}
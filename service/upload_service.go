@@ -0,0 +1,197 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	"github.com/ashalfarhan/realworld/storage"
+	"github.com/ashalfarhan/realworld/utils/logger"
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+)
+
+// Side of the square thumbnail generated for avatars
+const avatarThumbnailSize = 128
+
+// Sniffed content types accepted for upload, with the extension their blobs get
+var uploadExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type UploadService struct {
+	uploadRepo repository.UploadRepository
+	blobs      storage.BlobStore
+}
+
+func NewUploadService(repo *repository.Repository, blobs storage.BlobStore) *UploadService {
+	return &UploadService{
+		uploadRepo: repo.UploadRepo,
+		blobs:      blobs,
+	}
+}
+
+// The type is sniffed from the content, whatever the client claims it to be.
+// Only the header is decoded to check the dimensions, unless a thumbnail is needed.
+func (s *UploadService) Upload(ctx context.Context, username string, d *model.CreateUploadFields, file io.Reader) (*model.Upload, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	data, err := ioutil.ReadAll(io.LimitReader(file, config.UploadMaxSize+1))
+	if err != nil {
+		log.Warnln("Cannot read upload reason:", err)
+		return nil, conduit.BuildError(http.StatusBadRequest, err)
+	}
+	if int64(len(data)) > config.UploadMaxSize {
+		return nil, conduit.BuildError(http.StatusRequestEntityTooLarge, ErrUploadTooLarge)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := uploadExtensions[contentType]
+	if !ok {
+		return nil, conduit.BuildError(http.StatusUnsupportedMediaType, ErrUnsupportedUpload)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, conduit.BuildError(http.StatusUnsupportedMediaType, ErrUnsupportedUpload)
+	}
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.Width > config.UploadMaxDimension || cfg.Height > config.UploadMaxDimension {
+		return nil, conduit.BuildError(http.StatusUnprocessableEntity, ErrUploadDimensions)
+	}
+
+	id := uuid.NewString()
+	u := &model.Upload{
+		Username:    username,
+		Purpose:     d.Purpose,
+		Key:         fmt.Sprintf("%ss/%s%s", d.Purpose, id, ext),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}
+	if err := s.blobs.Put(ctx, u.Key, bytes.NewReader(data), u.Size, contentType); err != nil {
+		log.Warnf("Cannot put blob key:%q, reason: %v", u.Key, err)
+		return nil, conduit.GeneralError
+	}
+
+	if d.Purpose == model.UploadAvatar {
+		thumbKey := fmt.Sprintf("%ss/%s_thumb%s", d.Purpose, id, thumbnailExt(contentType))
+		if err := s.putThumbnail(ctx, thumbKey, data, contentType); err != nil {
+			log.Warnf("Cannot put thumbnail key:%q, reason: %v", thumbKey, err)
+			s.deleteBlobs(ctx, u)
+			return nil, conduit.GeneralError
+		}
+		u.ThumbnailKey = &thumbKey
+	}
+
+	if err := s.uploadRepo.InsertOne(ctx, u); err != nil {
+		log.Warnf("Cannot insert upload args:%+v, reason: %v", u, err)
+		s.deleteBlobs(ctx, u)
+		return nil, conduit.GeneralError
+	}
+	s.setURLs(u)
+	return u, nil
+}
+
+// Only the owner of an upload can delete it
+func (s *UploadService) DeleteUpload(ctx context.Context, username, id string) *model.ConduitError {
+	log := logger.GetCtx(ctx)
+	u, err := s.uploadRepo.FindOneByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return conduit.BuildError(http.StatusNotFound, ErrNoUploadFound)
+		}
+		log.Warnf("Cannot find upload id:%q, reason: %v", id, err)
+		return conduit.GeneralError
+	}
+	if u.Username != username {
+		return conduit.BuildError(http.StatusForbidden, ErrNotAllowedDeleteUpload)
+	}
+
+	if err := s.uploadRepo.DeleteOne(ctx, id); err != nil {
+		log.Warnf("Cannot delete upload id:%q, reason: %v", id, err)
+		return conduit.GeneralError
+	}
+	s.deleteBlobs(ctx, u)
+	return nil
+}
+
+func (s *UploadService) putThumbnail(ctx context.Context, key string, data []byte, contentType string) error {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	thumb := thumbnail(img, avatarThumbnailSize)
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(&buf, thumb)
+	}
+	if err != nil {
+		return err
+	}
+	return s.blobs.Put(ctx, key, &buf, int64(buf.Len()), thumbnailContentType(contentType))
+}
+
+// Removing blobs is best effort, an orphan blob is only wasted space
+func (s *UploadService) deleteBlobs(ctx context.Context, u *model.Upload) {
+	keys := []string{u.Key}
+	if u.ThumbnailKey != nil {
+		keys = append(keys, *u.ThumbnailKey)
+	}
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			logger.GetCtx(ctx).Warnf("Cannot delete blob key:%q, reason: %v", key, err)
+		}
+	}
+}
+
+func (s *UploadService) setURLs(u *model.Upload) {
+	u.URL = s.blobs.URL(u.Key)
+	if u.ThumbnailKey != nil {
+		thumbURL := s.blobs.URL(*u.ThumbnailKey)
+		u.ThumbnailURL = &thumbURL
+	}
+}
+
+// Crop the center square of img and scale it down to size x size
+func thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	crop := image.Rect(x0, y0, x0+side, y0+side)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Over, nil)
+	return dst
+}
+
+// GIF thumbnails are stills, kept as PNG
+func thumbnailContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return contentType
+	}
+	return "image/png"
+}
+
+func thumbnailExt(contentType string) string {
+	return uploadExtensions[thumbnailContentType(contentType)]
+}
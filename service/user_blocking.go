@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+func (s *UserService) BlockUser(ctx context.Context, username, blockUsername string) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST BlockUser blockUsername:%q, user:%q", blockUsername, username)
+	blocked, err := s.GetOneByUsername(ctx, blockUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if username == blocked.Username {
+		return nil, conduit.BuildError(http.StatusBadRequest, ErrSelfBlock)
+	}
+
+	if err := s.blockRepo.InsertOne(ctx, username, blocked.Username); err != nil {
+		switch err.Error() {
+		case repository.ErrDuplicateBlocking:
+			return nil, conduit.BuildError(http.StatusBadRequest, ErrAlreadyBlock)
+		default:
+			log.Warnln("Cannot insert to block repo reason:", err)
+			return nil, conduit.GeneralError
+		}
+	}
+	// Blocking drops the follows both ways
+	s.viewerCache.SetFollowing(ctx, username, blocked.Username, false)
+	s.viewerCache.SetFollowing(ctx, blocked.Username, username, false)
+	s.listCache.InvalidateUser(ctx, username)
+	s.listCache.InvalidateUser(ctx, blocked.Username)
+
+	res := blocked.Profile(false)
+	res.Blocking = true
+	return res, nil
+}
+
+func (s *UserService) UnblockUser(ctx context.Context, username, blockUsername string) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE UnblockUser blockUsername:%q, user:%q", blockUsername, username)
+	blocked, err := s.GetOneByUsername(ctx, blockUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.blockRepo.DeleteOne(ctx, username, blocked.Username); err != nil {
+		log.Warnln("Cannot delete from block repo reason:", err)
+		return nil, conduit.GeneralError
+	}
+	s.listCache.InvalidateUser(ctx, username)
+	s.listCache.InvalidateUser(ctx, blocked.Username)
+	return blocked.Profile(false), nil
+}
+
+func (s *UserService) MuteUser(ctx context.Context, username, muteUsername string) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST MuteUser muteUsername:%q, user:%q", muteUsername, username)
+	muted, err := s.GetOneByUsername(ctx, muteUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if username == muted.Username {
+		return nil, conduit.BuildError(http.StatusBadRequest, ErrSelfMute)
+	}
+
+	if err := s.muteRepo.InsertOne(ctx, username, muted.Username); err != nil {
+		switch err.Error() {
+		case repository.ErrDuplicateMuting:
+			return nil, conduit.BuildError(http.StatusBadRequest, ErrAlreadyMute)
+		default:
+			log.Warnln("Cannot insert to mute repo reason:", err)
+			return nil, conduit.GeneralError
+		}
+	}
+	s.listCache.InvalidateUser(ctx, username)
+
+	res := muted.Profile(s.IsFollowing(ctx, username, muted.Username))
+	res.Muting = true
+	return res, nil
+}
+
+func (s *UserService) UnmuteUser(ctx context.Context, username, muteUsername string) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE UnmuteUser muteUsername:%q, user:%q", muteUsername, username)
+	muted, err := s.GetOneByUsername(ctx, muteUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.muteRepo.DeleteOne(ctx, username, muted.Username); err != nil {
+		log.Warnln("Cannot delete from mute repo reason:", err)
+		return nil, conduit.GeneralError
+	}
+	s.listCache.InvalidateUser(ctx, username)
+	return muted.Profile(s.IsFollowing(ctx, username, muted.Username)), nil
+}
+
+func (s *UserService) IsBlocking(ctx context.Context, blockerUsername, blockedUsername string) bool {
+	ptr, err := s.blockRepo.FindOneByIDs(ctx, blockerUsername, blockedUsername)
+	return ptr != nil && err == nil
+}
+
+func (s *UserService) IsMuting(ctx context.Context, muterUsername, mutedUsername string) bool {
+	ptr, err := s.muteRepo.FindOneByIDs(ctx, muterUsername, mutedUsername)
+	return ptr != nil && err == nil
+}
+
+// Check whether any of the two users is blocking the other.
+// A failing lookup is an error rather than a pass, blocks must hold.
+func isBlockedEither(ctx context.Context, blocks repository.BlockingRepository, a, b string) (bool, *model.ConduitError) {
+	blocked, err := blocks.IsBlockedEither(ctx, a, b)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot check blocks between %q and %q, reason: %v", a, b, err)
+		return false, conduit.GeneralError
+	}
+	return blocked, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+	"github.com/ashalfarhan/realworld/persistence/repository"
+	"github.com/ashalfarhan/realworld/utils/logger"
+)
+
+func (s *UserService) RequestFollow(ctx context.Context, username string, target *model.User) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("RequestFollow target:%q, user:%q", target.Username, username)
+	if err := s.requestRepo.InsertOne(ctx, username, target.Username); err != nil {
+		switch err.Error() {
+		case repository.ErrDuplicateRequest:
+			return nil, conduit.BuildError(http.StatusBadRequest, ErrAlreadyRequested)
+		default:
+			log.Warnln("Cannot insert to follow request repo reason:", err)
+			return nil, conduit.GeneralError
+		}
+	}
+
+	res := target.Profile(false)
+	res.FollowRequested = true
+	return res, nil
+}
+
+func (s *UserService) ApproveFollowRequest(ctx context.Context, username, requesterUsername string) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("POST ApproveFollowRequest requester:%q, user:%q", requesterUsername, username)
+	requester, err := s.GetOneByUsername(ctx, requesterUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.requestRepo.Approve(ctx, requester.Username, username); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, conduit.BuildError(http.StatusNotFound, ErrNoFollowRequest)
+		}
+		log.Warnln("Cannot approve follow request reason:", err)
+		return nil, conduit.GeneralError
+	}
+	s.viewerCache.SetFollowing(ctx, requester.Username, username, true)
+	s.listCache.InvalidateUser(ctx, requester.Username)
+	return requester.Profile(s.IsFollowing(ctx, username, requester.Username)), nil
+}
+
+func (s *UserService) RejectFollowRequest(ctx context.Context, username, requesterUsername string) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	log.Infof("DELETE RejectFollowRequest requester:%q, user:%q", requesterUsername, username)
+	requester, err := s.GetOneByUsername(ctx, requesterUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.IsFollowRequested(ctx, requester.Username, username) {
+		return nil, conduit.BuildError(http.StatusNotFound, ErrNoFollowRequest)
+	}
+	if err := s.requestRepo.DeleteOne(ctx, requester.Username, username); err != nil {
+		log.Warnln("Cannot delete from follow request repo reason:", err)
+		return nil, conduit.GeneralError
+	}
+	return requester.Profile(s.IsFollowing(ctx, username, requester.Username)), nil
+}
+
+// Returns a page of the users requesting to follow args.Username and the total request count
+func (s *UserService) GetIncomingFollowRequests(ctx context.Context, args *model.FindFollowsArgs) ([]*model.ProfileRs, int, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	profiles, err := s.requestRepo.FindIncoming(ctx, args)
+	if err != nil {
+		log.Warnf("Cannot find incoming follow requests args:%+v, reason: %v", args, err)
+		return nil, 0, conduit.GeneralError
+	}
+	count, _, err := s.requestRepo.CountByUsername(ctx, args.Username)
+	if err != nil {
+		log.Warnf("Cannot count follow requests of user:%q, reason: %v", args.Username, err)
+		return nil, 0, conduit.GeneralError
+	}
+	return profiles, count, nil
+}
+
+// Returns a page of the users args.Username is requesting to follow and the total request count
+func (s *UserService) GetOutgoingFollowRequests(ctx context.Context, args *model.FindFollowsArgs) ([]*model.ProfileRs, int, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	profiles, err := s.requestRepo.FindOutgoing(ctx, args)
+	if err != nil {
+		log.Warnf("Cannot find outgoing follow requests args:%+v, reason: %v", args, err)
+		return nil, 0, conduit.GeneralError
+	}
+	_, count, err := s.requestRepo.CountByUsername(ctx, args.Username)
+	if err != nil {
+		log.Warnf("Cannot count follow requests of user:%q, reason: %v", args.Username, err)
+		return nil, 0, conduit.GeneralError
+	}
+	return profiles, count, nil
+}
+
+func (s *UserService) IsFollowRequested(ctx context.Context, requesterUsername, targetUsername string) bool {
+	ptr, err := s.requestRepo.FindOneByIDs(ctx, requesterUsername, targetUsername)
+	return ptr != nil && err == nil
+}
@@ -22,6 +22,17 @@ func (s *UserService) FollowUser(ctx context.Context, followUsername, username s
 		return nil, conduit.BuildError(http.StatusBadRequest, ErrSelfFollow)
 	}
 
+	if blocked, err := isBlockedEither(ctx, s.blockRepo, followUsername, following.Username); err != nil {
+		return nil, err
+	} else if blocked {
+		return nil, conduit.BuildError(http.StatusForbidden, ErrBlocked)
+	}
+
+	// Private profiles have to approve their followers first
+	if following.Private && !s.IsFollowing(ctx, followUsername, following.Username) {
+		return s.RequestFollow(ctx, followUsername, following)
+	}
+
 	if err := s.followRepo.InsertOne(ctx, followUsername, following.Username); err != nil {
 		switch err.Error() {
 		case repository.ErrDuplicateFollowing:
@@ -31,12 +42,21 @@ func (s *UserService) FollowUser(ctx context.Context, followUsername, username s
 			return nil, conduit.GeneralError
 		}
 	}
+	s.viewerCache.SetFollowing(ctx, followUsername, following.Username, true)
+	s.listCache.InvalidateUser(ctx, followUsername)
+
+	s.notifier.Notify(ctx, &model.CreateNotificationArgs{
+		Recipient: following.Username,
+		Actor:     followUsername,
+		Type:      model.NotificationFollow,
+	})
 
 	res := &model.ProfileRs{
 		Username:  following.Username,
 		Bio:       following.Bio,
 		Image:     following.Image,
 		Following: true,
+		Private:   following.Private,
 	}
 	return res, nil
 }
@@ -57,12 +77,21 @@ func (s *UserService) UnfollowUser(ctx context.Context, followUsername, username
 		log.Warnln("Cannot delete to follow repo reason:", followUsername, following.ID, err)
 		return nil, conduit.GeneralError
 	}
+	s.viewerCache.SetFollowing(ctx, followUsername, following.Username, false)
+	s.listCache.InvalidateUser(ctx, followUsername)
+
+	// Unfollowing also cancels a pending follow request
+	if err := s.requestRepo.DeleteOne(ctx, followUsername, following.Username); err != nil {
+		log.Warnln("Cannot delete to follow request repo reason:", followUsername, following.ID, err)
+		return nil, conduit.GeneralError
+	}
 
 	res := &model.ProfileRs{
 		Username:  following.Username,
 		Bio:       following.Bio,
 		Image:     following.Image,
 		Following: false,
+		Private:   following.Private,
 	}
 	return res, nil
 }
@@ -71,3 +100,35 @@ func (s *UserService) IsFollowing(ctx context.Context, followerUsername, followi
 	ptr, err := s.followRepo.FindOneByIDs(ctx, followerUsername, followingUsername)
 	return ptr != nil && err == nil
 }
+
+// Returns a page of the followers of args.Username and the total followers count
+func (s *UserService) GetFollowers(ctx context.Context, args *model.FindFollowsArgs) ([]*model.ProfileRs, int, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	p, err := s.GetProfile(ctx, args.Username, args.Viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	profiles, fErr := s.followRepo.FindFollowers(ctx, args)
+	if fErr != nil {
+		log.Warnf("Cannot find followers args:%+v, reason: %v", args, fErr)
+		return nil, 0, conduit.GeneralError
+	}
+	return profiles, *p.FollowersCount, nil
+}
+
+// Returns a page of the users args.Username is following and the total following count
+func (s *UserService) GetFollowings(ctx context.Context, args *model.FindFollowsArgs) ([]*model.ProfileRs, int, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
+	p, err := s.GetProfile(ctx, args.Username, args.Viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	profiles, fErr := s.followRepo.FindFollowings(ctx, args)
+	if fErr != nil {
+		log.Warnf("Cannot find followings args:%+v, reason: %v", args, fErr)
+		return nil, 0, conduit.GeneralError
+	}
+	return profiles, *p.FollowingCount, nil
+}
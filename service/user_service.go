@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"net/http"
 
+	"github.com/ashalfarhan/realworld/cache/store"
 	"github.com/ashalfarhan/realworld/conduit"
 	"github.com/ashalfarhan/realworld/model"
 	"github.com/ashalfarhan/realworld/persistence/repository"
@@ -13,14 +14,30 @@ import (
 )
 
 type UserService struct {
-	userRepo   repository.UserRepository
-	followRepo repository.FollowingRepository
+	userRepo     repository.UserRepository
+	followRepo   repository.FollowingRepository
+	blockRepo    repository.BlockingRepository
+	muteRepo     repository.MutingRepository
+	requestRepo  repository.FollowRequestRepository
+	articleRepo  repository.ArticleRepository
+	articleCache store.ArticleStore
+	viewerCache  store.ViewerStore
+	listCache    store.ListStore
+	notifier     *NotificationService
 }
 
-func NewUserService(repo *repository.Repository) *UserService {
+func NewUserService(repo *repository.Repository, store *store.CacheStore, notifier *NotificationService) *UserService {
 	return &UserService{
-		userRepo:   repo.UserRepo,
-		followRepo: repo.FollowRepo,
+		userRepo:     repo.UserRepo,
+		followRepo:   repo.FollowRepo,
+		blockRepo:    repo.BlockRepo,
+		muteRepo:     repo.MuteRepo,
+		requestRepo:  repo.FollowRequestRepo,
+		articleRepo:  repo.ArticleRepo,
+		articleCache: store.ArticleStore,
+		viewerCache:  store.ViewerStore,
+		listCache:    store.ListStore,
+		notifier:     notifier,
 	}
 }
 
@@ -71,11 +88,17 @@ func (s *UserService) Update(ctx context.Context, d *model.UpdateUserFields, use
 	if err != nil {
 		return nil, err
 	}
+	if err := checkVersion(u.Version, d.IfMatch, d.Version); err != nil {
+		return nil, err
+	}
 	if v := d.Password; v != nil {
 		hashed := s.HashPassword(*v)
 		d.Password = &hashed
 	}
 	if err := s.userRepo.UpdateOne(ctx, d, u); err != nil {
+		if err == repository.ErrStaleVersion {
+			return nil, staleVersionError(d.IfMatch)
+		}
 		switch err.Error() {
 		case repository.ErrDuplicateEmail:
 			return nil, conduit.BuildError(http.StatusBadRequest, ErrEmailExist)
@@ -86,20 +109,54 @@ func (s *UserService) Update(ctx context.Context, d *model.UpdateUserFields, use
 			return nil, conduit.GeneralError
 		}
 	}
+
+	// Going public let everyone waiting in along with the update,
+	// their cached following state catches up once it expires.
+	// The profile shows up as the author in everyone's lists, and in the
+	// cached articles, where it also decides who may read them
+	s.listCache.Invalidate(ctx)
+	s.invalidateAuthorArticles(ctx, u.Username)
 	return u, nil
 }
 
+func (s *UserService) invalidateAuthorArticles(ctx context.Context, username string) {
+	slugs, err := s.articleRepo.FindSlugsByAuthor(ctx, username)
+	if err != nil {
+		logger.GetCtx(ctx).Warnf("Cannot find the articles of %q to invalidate, reason: %v", username, err)
+		return
+	}
+	for _, slug := range slugs {
+		s.articleCache.InvalidateBySlug(ctx, slug)
+	}
+}
+
 func (s *UserService) HashPassword(p string) string {
 	hashed, _ := bcrypt.GenerateFromPassword([]byte(p), bcrypt.DefaultCost)
 	return string(hashed)
 }
 
 func (s *UserService) GetProfile(ctx context.Context, username, userID string) (*model.ProfileRs, *model.ConduitError) {
+	log := logger.GetCtx(ctx)
 	u, err := s.GetOneByUsername(ctx, username)
 	if err != nil {
 		return nil, err
 	}
-	following := s.IsFollowing(ctx, userID, u.ID)
+	following := s.IsFollowing(ctx, userID, u.Username)
 	res := u.Profile(following)
+
+	followers, followings, cErr := s.followRepo.CountByUsername(ctx, u.Username)
+	if cErr != nil {
+		log.Warnf("Cannot count follows of %q, reason: %v", u.Username, cErr)
+		return nil, conduit.GeneralError
+	}
+	res.FollowersCount = &followers
+	res.FollowingCount = &followings
+	if userID != "" {
+		if u.Private && !following {
+			res.FollowRequested = s.IsFollowRequested(ctx, userID, u.Username)
+		}
+		res.Blocking = s.IsBlocking(ctx, userID, u.Username)
+		res.Muting = s.IsMuting(ctx, userID, u.Username)
+	}
 	return res, nil
 }
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/ashalfarhan/realworld/conduit"
+	"github.com/ashalfarhan/realworld/model"
+)
+
+// An If-Match that names none of the current version fails its precondition,
+// a version in the body that is not the current one conflicts with an update
+// made in the meantime.
+func checkVersion(current int, ifMatch []int, version *int) *model.ConduitError {
+	if ifMatch != nil && !containsVersion(ifMatch, current) {
+		return conduit.BuildError(http.StatusPreconditionFailed, ErrPreconditionFailed)
+	}
+	if version != nil && *version != current {
+		return conduit.BuildError(http.StatusConflict, ErrVersionConflict)
+	}
+	return nil
+}
+
+// The row moved on between reading and writing it
+func staleVersionError(ifMatch []int) *model.ConduitError {
+	if ifMatch != nil {
+		return conduit.BuildError(http.StatusPreconditionFailed, ErrPreconditionFailed)
+	}
+	return conduit.BuildError(http.StatusConflict, ErrVersionConflict)
+}
+
+func containsVersion(versions []int, v int) bool {
+	for _, version := range versions {
+		if version == v {
+			return true
+		}
+	}
+	return false
+}
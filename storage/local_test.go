@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	as := assert.New(t)
+	dir := t.TempDir()
+	s := NewLocalStore(dir, "http://localhost:4000/uploads")
+	ctx := context.Background()
+
+	as.Nil(s.Put(ctx, "articles/key.png", strings.NewReader("image"), 5, "image/png"))
+	b, err := ioutil.ReadFile(filepath.Join(dir, "articles", "key.png"))
+	as.Nil(err)
+	as.Equal("image", string(b))
+	as.Equal("http://localhost:4000/uploads/articles/key.png", s.URL("articles/key.png"))
+
+	as.Nil(s.Delete(ctx, "articles/key.png"))
+	_, err = os.Stat(filepath.Join(dir, "articles", "key.png"))
+	as.True(os.IsNotExist(err))
+	as.Nil(s.Delete(ctx, "articles/key.png"), "Deleting a missing blob should not fail")
+
+	for _, key := range []string{"", "/abs", "a/../../b", "a//b"} {
+		as.Equal(ErrInvalidKey, s.Put(ctx, key, strings.NewReader(""), 0, ""), "Key %q must be rejected", key)
+	}
+}
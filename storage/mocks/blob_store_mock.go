@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BlobStoreMock struct {
+	mock.Mock
+}
+
+func (m *BlobStoreMock) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	args := m.Called(ctx, key, r, size, contentType)
+	return args.Error(0)
+}
+
+func (m *BlobStoreMock) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *BlobStoreMock) URL(key string) string {
+	args := m.Called(key)
+	return args.String(0)
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A minimal S3 stand-in that checks the signature of every request
+// and keeps the objects in memory
+type fakeS3 struct {
+	secret  string
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	if !f.verify(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = string(body)
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request, body []byte) bool {
+	auth := r.Header.Get("Authorization")
+	var credential, signedHeaders, signature string
+	if _, err := fmt.Sscanf(
+		strings.NewReplacer(",", " ").Replace(auth),
+		"AWS4-HMAC-SHA256 Credential=%s SignedHeaders=%s Signature=%s",
+		&credential, &signedHeaders, &signature,
+	); err != nil {
+		return false
+	}
+	parts := strings.SplitN(credential, "/", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	scope := parts[1]
+	date := strings.SplitN(scope, "/", 2)[0]
+
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		return false
+	}
+
+	var headers []string
+	for _, h := range strings.Split(signedHeaders, ";") {
+		v := r.Header.Get(h)
+		if h == "host" {
+			v = r.Host
+		}
+		headers = append(headers, h+":"+v)
+	}
+	canonical := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.RawQuery,
+		strings.Join(headers, "\n"), "", signedHeaders,
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	canonicalSum := sha256.Sum256([]byte(canonical))
+	toSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", r.Header.Get("X-Amz-Date"), scope, hex.EncodeToString(canonicalSum[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + f.secret)
+	for _, v := range []string{date, "us-east-1", "s3", "aws4_request", toSign} {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(v))
+		key = h.Sum(nil)
+	}
+	return hmac.Equal([]byte(hex.EncodeToString(key)), []byte(signature))
+}
+
+func TestS3Store(t *testing.T) {
+	as := assert.New(t)
+	fake := &fakeS3{secret: "secret", objects: map[string]string{}, types: map[string]string{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	opts := S3Options{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "conduit",
+		AccessKey: "access",
+		SecretKey: "secret",
+		BaseURL:   "https://cdn.example.com",
+	}
+	s := NewS3Store(opts)
+	ctx := context.Background()
+
+	err := s.Put(ctx, "avatars/a b.png", strings.NewReader("image"), 5, "image/png")
+	as.Nil(err)
+	as.Equal("image", fake.objects["/conduit/avatars/a b.png"])
+	as.Equal("image/png", fake.types["/conduit/avatars/a b.png"])
+	as.Equal("https://cdn.example.com/avatars/key.png", s.URL("avatars/key.png"))
+
+	as.Nil(s.Delete(ctx, "avatars/a b.png"))
+	as.Empty(fake.objects)
+
+	as.Equal(ErrInvalidKey, s.Put(ctx, "../escape", strings.NewReader(""), 0, "image/png"))
+
+	opts.SecretKey = "wrong"
+	err = NewS3Store(opts).Put(ctx, "avatars/key.png", strings.NewReader("image"), 5, "image/png")
+	if as.NotNil(err) {
+		as.Contains(err.Error(), "403")
+	}
+}
+
+func TestS3StoreSignatureIsStable(t *testing.T) {
+	as := assert.New(t)
+	s := NewS3Store(S3Options{Endpoint: "http://localhost:9000", Region: "us-east-1", Bucket: "b", AccessKey: "ak", SecretKey: "sk"})
+	s.now = func() time.Time { return time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	req, _ := http.NewRequest(http.MethodDelete, s.objectURL("k"), nil)
+	s.sign(req, nil)
+	as.Equal("20220501T120000Z", req.Header.Get("X-Amz-Date"))
+	as.True(strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=ak/20220501/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+}
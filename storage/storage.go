@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ashalfarhan/realworld/config"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// Keeps the uploaded files, keys are slash separated paths like "avatars/{id}.png"
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// Public URL of the blob
+	URL(key string) string
+}
+
+func Init() BlobStore {
+	switch config.UploadDriver {
+	case "local":
+		baseURL := config.UploadBaseURL
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("http://localhost:%s/uploads", config.Port)
+		}
+		logrus.Printf("Storing uploads in %q", config.UploadDir)
+		return NewLocalStore(config.UploadDir, baseURL)
+	case "s3":
+		if config.S3Endpoint == "" || config.S3Bucket == "" {
+			logrus.Panicln("S3_ENDPOINT and S3_BUCKET are required by the s3 upload driver")
+		}
+		baseURL := config.UploadBaseURL
+		if baseURL == "" {
+			baseURL = config.S3Endpoint + "/" + config.S3Bucket
+		}
+		logrus.Printf("Storing uploads in bucket %q", config.S3Bucket)
+		return NewS3Store(S3Options{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+			BaseURL:   baseURL,
+		})
+	default:
+		logrus.Panicf("Unknown upload driver %q", config.UploadDriver)
+		return nil
+	}
+}
+
+// Keys are generated by the server, this only guards against escaping the store
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
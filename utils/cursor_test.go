@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	as := assert.New(t)
+	now := time.Date(2022, 3, 4, 5, 6, 7, 891011000, time.UTC)
+
+	ts, id, err := DecodeCursor(EncodeCursor(now, "comment-id"))
+	as.Nil(err)
+	as.True(now.Equal(ts), "Time must keep its sub-second precision")
+	as.Equal("comment-id", id)
+
+	for _, c := range []string{"", "not base64!", EncodeCursor(now, "")} {
+		_, _, err = DecodeCursor(c)
+		as.Equal(ErrInvalidCursor, err, "Cursor %q must be rejected", c)
+	}
+}
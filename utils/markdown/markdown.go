@@ -0,0 +1,142 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/ashalfarhan/realworld/utils"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Where a mention links to, followed by the username
+const MentionPath = "/profile/"
+
+var (
+	mentionsKey = parser.NewContextKey()
+
+	// Raw HTML is let through the renderer, the sanitizer decides what stays
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.Linkify),
+		goldmark.WithParserOptions(
+			parser.WithASTTransformers(util.Prioritized(mentionTransformer{}, 100)),
+		),
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+
+	policy = newPolicy()
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^mention$`)).OnElements("a")
+	return p
+}
+
+// Render markdown into sanitized HTML.
+// Only the "@username" of the given mentions are turned into profile links,
+// the caller is expected to pass the mentions that were checked against existing users.
+func Render(src string, mentions []string) (string, error) {
+	known := make(map[string]bool, len(mentions))
+	for _, m := range mentions {
+		known[m] = true
+	}
+	ctx := parser.NewContext()
+	ctx.Set(mentionsKey, known)
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf, parser.WithContext(ctx)); err != nil {
+		return "", err
+	}
+	return string(policy.SanitizeBytes(buf.Bytes())), nil
+}
+
+type mentionTransformer struct{}
+
+func (mentionTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	known, _ := pc.Get(mentionsKey).(map[string]bool)
+	if len(known) == 0 {
+		return
+	}
+
+	// Collect first, the tree cannot be changed while walking it
+	var texts []*ast.Text
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Link, *ast.AutoLink, *ast.CodeSpan, *ast.CodeBlock, *ast.FencedCodeBlock:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			texts = append(texts, n)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	source := reader.Source()
+	merged := map[*ast.Text]bool{}
+	for _, t := range texts {
+		if merged[t] {
+			continue
+		}
+		mergeFollowingTexts(t, merged)
+		linkMentions(t, source, known)
+	}
+}
+
+// The inline parser splits text at characters that may open emphasis,
+// like the "_" in "@jake_doe". Adjacent pieces of one run of source
+// are joined again, so a mention is matched whole.
+func mergeFollowingTexts(t *ast.Text, merged map[*ast.Text]bool) {
+	for !t.SoftLineBreak() && !t.HardLineBreak() && !t.IsRaw() {
+		next, ok := t.NextSibling().(*ast.Text)
+		if !ok || next.IsRaw() || next.Segment.Start != t.Segment.Stop {
+			return
+		}
+		t.Segment = text.NewSegment(t.Segment.Start, next.Segment.Stop)
+		t.SetSoftLineBreak(next.SoftLineBreak())
+		t.SetHardLineBreak(next.HardLineBreak())
+		next.Parent().RemoveChild(next.Parent(), next)
+		merged[next] = true
+	}
+}
+
+// Split a text node around the known mentions it contains
+func linkMentions(t *ast.Text, source []byte, known map[string]bool) {
+	seg := t.Segment
+	value := string(seg.Value(source))
+	parent := t.Parent()
+	replaced := false
+	start := 0
+	for _, loc := range utils.MentionIndexes(value) {
+		username := value[loc[0]+1 : loc[1]]
+		if !known[username] {
+			continue
+		}
+		if loc[0] > start {
+			parent.InsertBefore(parent, t, ast.NewTextSegment(text.NewSegment(seg.Start+start, seg.Start+loc[0])))
+		}
+		link := ast.NewLink()
+		link.Destination = []byte(MentionPath + username)
+		link.SetAttributeString("class", []byte("mention"))
+		link.AppendChild(link, ast.NewTextSegment(text.NewSegment(seg.Start+loc[0], seg.Start+loc[1])))
+		parent.InsertBefore(parent, t, link)
+		start, replaced = loc[1], true
+	}
+	if !replaced {
+		return
+	}
+	// What follows the last mention keeps the line break of the original node
+	rest := ast.NewTextSegment(text.NewSegment(seg.Start+start, seg.Stop))
+	rest.SetSoftLineBreak(t.SoftLineBreak())
+	rest.SetHardLineBreak(t.HardLineBreak())
+	parent.InsertBefore(parent, t, rest)
+	parent.RemoveChild(parent, t)
+}
@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	as := assert.New(t)
+
+	out, err := Render("# Title\n\nSome **bold** and ~~gone~~ text", nil)
+	as.Nil(err)
+	as.Equal("<h1>Title</h1>\n<p>Some <strong>bold</strong> and <del>gone</del> text</p>\n", out)
+
+	out, err = Render(`<script>alert(1)</script><a href="javascript:alert(1)" onclick="x()">click</a>
+
+[me](javascript:alert(1))`, nil)
+	as.Nil(err)
+	as.NotContains(out, "<script")
+	as.NotContains(out, "javascript:")
+	as.NotContains(out, "onclick")
+}
+
+func TestRenderMentions(t *testing.T) {
+	as := assert.New(t)
+
+	out, err := Render("Thanks @jake and @ghost.\nSee `@jake` or [@jake](/x), mail jake@example.com", []string{"jake"})
+	as.Nil(err)
+	as.Equal(
+		"<p>Thanks <a href=\"/profile/jake\" class=\"mention\" rel=\"nofollow\">@jake</a> and @ghost.\n"+
+			"See <code>@jake</code> or <a href=\"/x\" rel=\"nofollow\">@jake</a>, mail "+
+			"<a href=\"mailto:jake@example.com\" rel=\"nofollow\">jake@example.com</a></p>\n",
+		out,
+	)
+}
+
+func TestRenderMentionsWithUnderscore(t *testing.T) {
+	as := assert.New(t)
+
+	out, err := Render("hi @jake_doe there, not @jake_smith", []string{"jake_doe", "jake"})
+	as.Nil(err)
+	as.Equal(
+		"<p>hi <a href=\"/profile/jake_doe\" class=\"mention\" rel=\"nofollow\">@jake_doe</a> there, not @jake_smith</p>\n",
+		out,
+	)
+}
@@ -0,0 +1,25 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainText(t *testing.T) {
+	as := assert.New(t)
+	src := "# Intro\n\nSome **bold** and [a link](https://example.com),\nsee <https://go.dev>.\n\n" +
+		"```go\nfunc main() {}\n```\n\n![diagram](d.png)\n<div>raw</div>\n\n- one\n- two"
+
+	as.Equal("Intro Some bold and a link, see https://go.dev. one two", PlainText(src))
+	as.Equal("", PlainText(""))
+	as.Equal("Tom & Jerry", PlainText("Tom &amp; Jerry"))
+}
+
+func TestExcerpt(t *testing.T) {
+	as := assert.New(t)
+
+	as.Equal("Short *enough*", Excerpt("Short \\*enough\\*", 20))
+	as.Equal("The quick brown…", Excerpt("The **quick** brown, fox jumps", 20))
+	as.Equal("Ünïcödé…", Excerpt("Ünïcödé wörds", 10))
+}
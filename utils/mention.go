@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// An "@" only starts a mention at the beginning of the text or after a non-word character,
+// so email addresses like "jake@example.com" are not mistaken for mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.-]*)`)
+
+const (
+	// Same as the max length of a username on register
+	maxMentionLength = 40
+	// Anything past this is ignored, to keep a body from notifying everyone
+	MaxMentions = 20
+)
+
+// Returns the distinct usernames mentioned in body, in order of first appearance
+func ParseMentions(body string) []string {
+	mentions := []string{}
+	seen := make(map[string]bool)
+	for _, loc := range MentionIndexes(body) {
+		username := body[loc[0]+1 : loc[1]]
+		if len(username) > maxMentionLength || seen[username] {
+			continue
+		}
+		seen[username] = true
+		if mentions = append(mentions, username); len(mentions) == MaxMentions {
+			break
+		}
+	}
+	return mentions
+}
+
+// Returns the start and end offsets of every "@username" in text
+func MentionIndexes(text string) [][2]int {
+	locs := [][2]int{}
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		// Punctuation ending a sentence is not part of the username
+		username := strings.TrimRight(text[m[2]:m[3]], ".-")
+		locs = append(locs, [2]int{m[2] - 1, m[2] + len(username)})
+	}
+	return locs
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	as := assert.New(t)
+
+	as.Equal([]string{}, ParseMentions("No mentions, mail jake@example.com"))
+	as.Equal(
+		[]string{"jake", "jane_doe", "john.smith"},
+		ParseMentions("@jake thanks! cc (@jane_doe), @john.smith. And @jake again"),
+	)
+	as.Equal([]string{}, ParseMentions("@"+strings.Repeat("a", 41)), "Too long to be a username")
+
+	var b strings.Builder
+	for i := 0; i < MaxMentions+5; i++ {
+		fmt.Fprintf(&b, "@user%d ", i)
+	}
+	as.Len(ParseMentions(b.String()), MaxMentions)
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ReqCtxKey string
 
@@ -17,3 +20,19 @@ func GetReqID(ctx context.Context) string {
 	}
 	return id
 }
+
+// Keeps the values of a request context, like its ID for the logs,
+// but is never cancelled along with it.
+// Work shared by several requests must not end when the first one does.
+func DetachCtx(ctx context.Context) context.Context {
+	return detachedCtx{ctx}
+}
+
+type detachedCtx struct {
+	parent context.Context
+}
+
+func (detachedCtx) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedCtx) Done() <-chan struct{}               { return nil }
+func (detachedCtx) Err() error                          { return nil }
+func (c detachedCtx) Value(key interface{}) interface{} { return c.parent.Value(key) }
@@ -11,3 +11,14 @@ func TestCreateReqID(t *testing.T) {
 	ctx := CreateReqIDCtx(context.TODO(), "request-id")
 	assert.Equal(t, "request-id", ctx.Value(reqCtx), "Request must be set")
 }
+
+func TestDetachCtx(t *testing.T) {
+	as := assert.New(t)
+	ctx, cancel := context.WithCancel(CreateReqIDCtx(context.TODO(), "request-id"))
+	detached := DetachCtx(ctx)
+	cancel()
+
+	as.Error(ctx.Err())
+	as.NoError(detached.Err(), "Cancelling the request should not reach the detached context")
+	as.Equal("request-id", GetReqID(detached), "Values should still be found")
+}